@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
@@ -17,9 +18,15 @@ import (
 
 	"github.com/avast/retry-go/v4"
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	paramsutils "github.com/cosmos/cosmos-sdk/x/params/client/utils"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	ibcexported "github.com/cosmos/ibc-go/v4/modules/core/exported"
+	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	dockerclient "github.com/docker/docker/client"
@@ -58,6 +65,12 @@ type ChainNode struct {
 	// Ports set during StartContainer.
 	hostRPCPort  string
 	hostGRPCPort string
+
+	// Container state changed by StopContainer, PauseContainer and their counterparts,
+	// so that queries can be routed away from unavailable nodes.
+	stateLock sync.Mutex
+	stopped   bool
+	paused    bool
 }
 
 // ChainNodes is a collection of ChainNode
@@ -176,6 +189,43 @@ type PrivValidatorKeyFile struct {
 	PrivKey PrivValidatorKey `json:"priv_key"`
 }
 
+func (tn *ChainNode) privValidatorKeyContent(ctx context.Context) ([]byte, error) {
+	fr := dockerutil.NewFileRetriever(tn.logger(), tn.DockerClient, tn.TestName)
+	key, err := fr.SingleFileContent(ctx, tn.VolumeName, "config/priv_validator_key.json")
+	if err != nil {
+		return nil, fmt.Errorf("getting priv_validator_key.json content: %w", err)
+	}
+
+	return key, nil
+}
+
+func (tn *ChainNode) overwritePrivValidatorKey(ctx context.Context, content []byte) error {
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fw.WriteFile(ctx, tn.VolumeName, "config/priv_validator_key.json", content); err != nil {
+		return fmt.Errorf("overwriting priv_validator_key.json: %w", err)
+	}
+
+	return nil
+}
+
+// ConsensusAddress returns the bech32 consensus address of the node's private validator key,
+// as used by the slashing module.
+func (tn *ChainNode) ConsensusAddress(ctx context.Context) (string, error) {
+	content, err := tn.privValidatorKeyContent(ctx)
+	if err != nil {
+		return "", err
+	}
+	var key PrivValidatorKeyFile
+	if err := json.Unmarshal(content, &key); err != nil {
+		return "", fmt.Errorf("parsing priv_validator_key.json: %w", err)
+	}
+	addr, err := hex.DecodeString(key.Address)
+	if err != nil {
+		return "", fmt.Errorf("decoding validator address %q: %w", key.Address, err)
+	}
+	return bech32.ConvertAndEncode(tn.Chain.Config().Bech32Prefix+"valcons", addr)
+}
+
 // Bind returns the home folder bind point for running the node
 func (tn *ChainNode) Bind() []string {
 	return []string{fmt.Sprintf("%s:%s", tn.VolumeName, tn.HomeDir())}
@@ -185,7 +235,8 @@ func (tn *ChainNode) HomeDir() string {
 	return path.Join("/var/cosmos-chain", tn.Chain.Config().Name)
 }
 
-// SetValidatorConfigAndPeers modifies the config for a validator node to start a chain
+// SetValidatorConfigAndPeers modifies the config for a validator node to start a chain.
+// The chain's config file overrides for the node's type are applied last.
 func (tn *ChainNode) SetValidatorConfigAndPeers(ctx context.Context, peers string) error {
 	// Pull default config
 	cfg := tmconfig.DefaultConfig()
@@ -211,14 +262,47 @@ func (tn *ChainNode) SetValidatorConfigAndPeers(ctx context.Context, peers strin
 		return fmt.Errorf("reading temporary config file: %w", err)
 	}
 
+	overrides := tn.configOverrides()
+	if len(overrides.ConfigToml) > 0 {
+		content, err = applyTomlOverrides(content, overrides.ConfigToml)
+		if err != nil {
+			return fmt.Errorf("applying config.toml overrides: %w", err)
+		}
+	}
+
 	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
 	if err := fw.WriteFile(ctx, tn.VolumeName, "config/config.toml", content); err != nil {
 		return fmt.Errorf("overwriting config.toml: %w", err)
 	}
 
+	if len(overrides.AppToml) == 0 {
+		return nil
+	}
+
+	fr := dockerutil.NewFileRetriever(tn.logger(), tn.DockerClient, tn.TestName)
+	appContent, err := fr.SingleFileContent(ctx, tn.VolumeName, "config/app.toml")
+	if err != nil {
+		return fmt.Errorf("getting app.toml content: %w", err)
+	}
+	appContent, err = applyTomlOverrides(appContent, overrides.AppToml)
+	if err != nil {
+		return fmt.Errorf("applying app.toml overrides: %w", err)
+	}
+	if err := fw.WriteFile(ctx, tn.VolumeName, "config/app.toml", appContent); err != nil {
+		return fmt.Errorf("overwriting app.toml: %w", err)
+	}
+
 	return nil
 }
 
+// configOverrides returns the chain's config file overrides for the node's type.
+func (tn *ChainNode) configOverrides() ibc.NodeConfigOverrides {
+	if tn.Validator {
+		return tn.Chain.Config().ValidatorConfig
+	}
+	return tn.Chain.Config().FullNodeConfig
+}
+
 func (tn *ChainNode) Height(ctx context.Context) (uint64, error) {
 	res, err := tn.Client.Status(ctx)
 	if err != nil {
@@ -459,6 +543,214 @@ func (tn *ChainNode) SendFunds(ctx context.Context, keyName string, amount ibc.W
 	return tn.ExecThenWaitForBlocks(ctx, command)
 }
 
+// Vote options accepted by VoteOnProposal.
+const (
+	ProposalVoteYes        = "yes"
+	ProposalVoteNo         = "no"
+	ProposalVoteNoWithVeto = "noWithVeto"
+	ProposalVoteAbstain    = "abstain"
+)
+
+// SoftwareUpgradeProposal holds the fields of a software-upgrade governance proposal.
+type SoftwareUpgradeProposal struct {
+	Deposit     string // amount deposited with the proposal, e.g. "10000000stake"
+	Title       string
+	Name        string // upgrade plan name; must match an upgrade handler registered in the new binary
+	Description string
+	Height      uint64 // height at which the chain halts for the upgrade
+	Info        string // optional upgrade info, e.g. binary download links
+}
+
+// CosmosTx is the JSON output of a broadcast transaction.
+type CosmosTx struct {
+	TxHash string `json:"txhash"`
+	Code   int    `json:"code"`
+	RawLog string `json:"raw_log"`
+}
+
+// TextProposal holds the fields of a text governance proposal.
+type TextProposal struct {
+	Deposit     string // amount deposited with the proposal, e.g. "10000000stake"
+	Title       string
+	Description string
+}
+
+// ClientUpdateProposal holds the fields of a governance proposal
+// to replace an expired or frozen IBC client with a substitute client.
+type ClientUpdateProposal struct {
+	Deposit            string // amount deposited with the proposal, e.g. "10000000stake"
+	Title              string
+	Description        string
+	SubjectClientID    string // the expired or frozen client
+	SubstituteClientID string // an active client tracking the same chain
+}
+
+// TextProposal submits a text governance proposal signed by keyName,
+// returning the hash of the submitted transaction.
+func (tn *ChainNode) TextProposal(ctx context.Context, keyName string, prop TextProposal) (string, error) {
+	return tn.submitProposal(ctx, keyName,
+		"--type", "Text",
+		"--title", prop.Title,
+		"--description", prop.Description,
+		"--deposit", prop.Deposit,
+	)
+}
+
+// ParamChangeProposal submits a parameter change governance proposal signed by keyName,
+// returning the hash of the submitted transaction.
+func (tn *ChainNode) ParamChangeProposal(ctx context.Context, keyName string, prop paramsutils.ParamChangeProposalJSON) (string, error) {
+	content, err := json.Marshal(prop)
+	if err != nil {
+		return "", err
+	}
+
+	// The CLI only accepts param change proposals from a file.
+	relPath := fmt.Sprintf("param-change-%x.json", sha256.Sum256(content))
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fw.WriteFile(ctx, tn.VolumeName, relPath, content); err != nil {
+		return "", fmt.Errorf("writing param change proposal: %w", err)
+	}
+
+	return tn.submitProposal(ctx, keyName, "param-change", filepath.Join(tn.HomeDir(), relPath))
+}
+
+// ClientUpdateProposal submits a governance proposal to substitute an IBC client, signed by keyName,
+// returning the hash of the submitted transaction.
+func (tn *ChainNode) ClientUpdateProposal(ctx context.Context, keyName string, prop ClientUpdateProposal) (string, error) {
+	return tn.submitProposal(ctx, keyName, "update-client", prop.SubjectClientID, prop.SubstituteClientID,
+		"--title", prop.Title,
+		"--description", prop.Description,
+		"--deposit", prop.Deposit,
+	)
+}
+
+// UpgradeProposal submits a software-upgrade governance proposal signed by keyName,
+// returning the hash of the submitted transaction.
+func (tn *ChainNode) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (string, error) {
+	args := []string{"software-upgrade", prop.Name,
+		"--upgrade-height", strconv.FormatUint(prop.Height, 10),
+		"--title", prop.Title,
+		"--description", prop.Description,
+		"--deposit", prop.Deposit,
+	}
+	if prop.Info != "" {
+		args = append(args, "--upgrade-info", prop.Info)
+	}
+	return tn.submitProposal(ctx, keyName, args...)
+}
+
+// submitProposal runs "tx gov submit-proposal" with the given arguments, signed by keyName,
+// returning the hash of the submitted transaction.
+func (tn *ChainNode) submitProposal(ctx context.Context, keyName string, args ...string) (string, error) {
+	command := append([]string{tn.Chain.Config().Bin, "tx", "gov", "submit-proposal"}, args...)
+	command = append(command,
+		"--keyring-backend", keyring.BackendTest,
+		"--gas-prices", tn.Chain.Config().GasPrices,
+		"--gas-adjustment", fmt.Sprint(tn.Chain.Config().GasAdjustment),
+		"--node", fmt.Sprintf("tcp://%s:26657", tn.HostName()),
+		"--from", keyName,
+		"--output", "json",
+		"-y",
+		"--home", tn.HomeDir(),
+		"--chain-id", tn.Chain.Config().ChainID,
+	)
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+	stdout, _, err := tn.Exec(ctx, command, nil)
+	if err != nil {
+		return "", err
+	}
+	var output CosmosTx
+	if err := json.Unmarshal(stdout, &output); err != nil {
+		return "", fmt.Errorf("parse proposal output: %w", err)
+	}
+	if output.Code != 0 {
+		return output.TxHash, fmt.Errorf("proposal transaction failed with code %d: %s", output.Code, output.RawLog)
+	}
+	if err := test.WaitForBlocks(ctx, 2, tn); err != nil {
+		return "", fmt.Errorf("wait for blocks: %w", err)
+	}
+	return output.TxHash, nil
+}
+
+// DepositOnProposal deposits amount, e.g. "10000000stake", from keyName on a governance proposal.
+func (tn *ChainNode) DepositOnProposal(ctx context.Context, keyName string, proposalID string, amount string) error {
+	command := []string{tn.Chain.Config().Bin, "tx", "gov", "deposit", proposalID, amount,
+		"--keyring-backend", keyring.BackendTest,
+		"--gas-prices", tn.Chain.Config().GasPrices,
+		"--gas-adjustment", fmt.Sprint(tn.Chain.Config().GasAdjustment),
+		"--node", fmt.Sprintf("tcp://%s:26657", tn.HostName()),
+		"--from", keyName,
+		"--output", "json",
+		"-y",
+		"--home", tn.HomeDir(),
+		"--chain-id", tn.Chain.Config().ChainID,
+	}
+	return tn.ExecThenWaitForBlocks(ctx, command)
+}
+
+// VoteOnProposal casts keyName's vote on a governance proposal.
+// Vote must be one of the ProposalVote constants.
+func (tn *ChainNode) VoteOnProposal(ctx context.Context, keyName string, proposalID string, vote string) error {
+	command := []string{tn.Chain.Config().Bin, "tx", "gov", "vote", proposalID, vote,
+		"--keyring-backend", keyring.BackendTest,
+		"--gas-prices", tn.Chain.Config().GasPrices,
+		"--gas-adjustment", fmt.Sprint(tn.Chain.Config().GasAdjustment),
+		"--node", fmt.Sprintf("tcp://%s:26657", tn.HostName()),
+		"--from", keyName,
+		"--output", "json",
+		"-y",
+		"--home", tn.HomeDir(),
+		"--chain-id", tn.Chain.Config().ChainID,
+	}
+	return tn.ExecThenWaitForBlocks(ctx, command)
+}
+
+// UpdateClient submits header as an update to the IBC client with the given ID, signed by keyName,
+// returning the hash of the submitted transaction.
+func (tn *ChainNode) UpdateClient(ctx context.Context, keyName, clientID string, header ibcexported.Header) (string, error) {
+	content, err := defaultEncoding.Marshaler.MarshalInterfaceJSON(header)
+	if err != nil {
+		return "", fmt.Errorf("encoding header: %w", err)
+	}
+
+	relPath := fmt.Sprintf("client-update-%x.json", sha256.Sum256(content))
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fw.WriteFile(ctx, tn.VolumeName, relPath, content); err != nil {
+		return "", fmt.Errorf("writing header: %w", err)
+	}
+
+	command := []string{tn.Chain.Config().Bin, "tx", "ibc", "client", "update", clientID, filepath.Join(tn.HomeDir(), relPath),
+		"--keyring-backend", keyring.BackendTest,
+		"--gas", "auto",
+		"--gas-prices", tn.Chain.Config().GasPrices,
+		"--gas-adjustment", fmt.Sprint(tn.Chain.Config().GasAdjustment),
+		"--node", fmt.Sprintf("tcp://%s:26657", tn.HostName()),
+		"--from", keyName,
+		"--output", "json",
+		"-y",
+		"--home", tn.HomeDir(),
+		"--chain-id", tn.Chain.Config().ChainID,
+	}
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+	stdout, _, err := tn.Exec(ctx, command, nil)
+	if err != nil {
+		return "", err
+	}
+	var output CosmosTx
+	if err := json.Unmarshal(stdout, &output); err != nil {
+		return "", fmt.Errorf("parse client update output: %w", err)
+	}
+	if output.Code != 0 {
+		return output.TxHash, fmt.Errorf("client update transaction failed with code %d: %s", output.Code, output.RawLog)
+	}
+	if err := test.WaitForBlocks(ctx, 2, tn); err != nil {
+		return "", fmt.Errorf("wait for blocks: %w", err)
+	}
+	return output.TxHash, nil
+}
+
 func (tn *ChainNode) ExecThenWaitForBlocks(ctx context.Context, command []string) error {
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
@@ -727,10 +1019,13 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 	return nil
 }
 
+// StartContainer starts the node's container, which may have been stopped by StopContainer,
+// and waits for the node to respond.
 func (tn *ChainNode) StartContainer(ctx context.Context) error {
 	if err := dockerutil.StartContainer(ctx, tn.DockerClient, tn.containerID); err != nil {
 		return err
 	}
+	tn.setState(false, false)
 
 	c, err := tn.DockerClient.ContainerInspect(ctx, tn.containerID)
 	if err != nil {
@@ -763,6 +1058,94 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 	}, retry.Context(ctx), retry.Attempts(40), retry.Delay(3*time.Second), retry.DelayType(retry.FixedDelay))
 }
 
+// StopContainer stops the node's container, leaving its volume intact.
+// The container can be started again with StartContainer.
+func (tn *ChainNode) StopContainer(ctx context.Context) error {
+	timeout := 30 * time.Second
+	if err := tn.DockerClient.ContainerStop(ctx, tn.containerID, &timeout); err != nil {
+		return err
+	}
+	tn.setState(true, false)
+	return nil
+}
+
+// RestartContainer stops the node's container and starts it again.
+func (tn *ChainNode) RestartContainer(ctx context.Context) error {
+	if err := tn.StopContainer(ctx); err != nil {
+		return fmt.Errorf("stop container: %w", err)
+	}
+	return tn.StartContainer(ctx)
+}
+
+// PauseContainer suspends all processes in the node's container, without stopping it.
+// The node's peers and clients see it as unresponsive rather than gone.
+func (tn *ChainNode) PauseContainer(ctx context.Context) error {
+	if err := tn.DockerClient.ContainerPause(ctx, tn.containerID); err != nil {
+		return err
+	}
+	tn.setState(false, true)
+	return nil
+}
+
+// UnpauseContainer resumes the processes in a container paused by PauseContainer.
+func (tn *ChainNode) UnpauseContainer(ctx context.Context) error {
+	if err := tn.DockerClient.ContainerUnpause(ctx, tn.containerID); err != nil {
+		return err
+	}
+	tn.setState(false, false)
+	return nil
+}
+
+// DisconnectNetwork partitions the node from the test network,
+// so that it can reach neither its peers nor relayers, nor be reached by them.
+// The node remains reachable from the host.
+func (tn *ChainNode) DisconnectNetwork(ctx context.Context) error {
+	return dockerutil.DisconnectNetwork(ctx, tn.DockerClient, tn.NetworkID, tn.containerID)
+}
+
+// ReconnectNetwork heals a partition created by DisconnectNetwork.
+func (tn *ChainNode) ReconnectNetwork(ctx context.Context) error {
+	return dockerutil.ConnectNetwork(ctx, tn.DockerClient, tn.NetworkID, tn.containerID)
+}
+
+// SetNetworkConditions degrades the node's outgoing network traffic,
+// replacing any conditions previously set.
+func (tn *ChainNode) SetNetworkConditions(ctx context.Context, cond ibc.NetworkConditions) error {
+	return dockerutil.SetNetem(ctx, tn.logger(), tn.DockerClient, tn.TestName, tn.containerID, cond.Latency, cond.Jitter, cond.PacketLoss)
+}
+
+// ClearNetworkConditions restores the node's network traffic after SetNetworkConditions.
+func (tn *ChainNode) ClearNetworkConditions(ctx context.Context) error {
+	return dockerutil.ClearNetem(ctx, tn.logger(), tn.DockerClient, tn.TestName, tn.containerID)
+}
+
+// IsAvailable reports whether the node's container has not been stopped or paused.
+func (tn *ChainNode) IsAvailable() bool {
+	tn.stateLock.Lock()
+	defer tn.stateLock.Unlock()
+	return !tn.stopped && !tn.paused
+}
+
+func (tn *ChainNode) setState(stopped, paused bool) {
+	tn.stateLock.Lock()
+	defer tn.stateLock.Unlock()
+	tn.stopped = stopped
+	tn.paused = paused
+}
+
+// RemoveContainer removes the node's container, leaving its volume intact,
+// so that a new container can be created with CreateNodeContainer.
+func (tn *ChainNode) RemoveContainer(ctx context.Context) error {
+	err := tn.DockerClient.ContainerRemove(ctx, tn.containerID, dockertypes.ContainerRemoveOptions{
+		Force: true,
+	})
+	if err != nil {
+		return err
+	}
+	tn.containerID = ""
+	return nil
+}
+
 // InitValidatorFiles creates the node files and signs a genesis transaction
 func (tn *ChainNode) InitValidatorFiles(
 	ctx context.Context,
@@ -773,6 +1156,17 @@ func (tn *ChainNode) InitValidatorFiles(
 	if err := tn.InitHomeFolder(ctx); err != nil {
 		return err
 	}
+	return tn.InitValidatorGenTx(ctx, chainType, genesisAmounts, genesisSelfDelegation)
+}
+
+// InitValidatorGenTx creates the validator key in an initialized home folder,
+// adds the key's genesis account and signs a genesis transaction
+func (tn *ChainNode) InitValidatorGenTx(
+	ctx context.Context,
+	chainType *ibc.ChainConfig,
+	genesisAmounts []types.Coin,
+	genesisSelfDelegation types.Coin,
+) error {
 	if err := tn.CreateKey(ctx, valKey); err != nil {
 		return err
 	}
@@ -826,6 +1220,40 @@ func (tn *ChainNode) KeyBech32(ctx context.Context, name string) (string, error)
 	return string(bytes.TrimSuffix(stdout, []byte("\n"))), nil
 }
 
+// ValidatorOperatorAddress returns the bech32 operator address of the node's validator key,
+// as used by the staking module.
+func (tn *ChainNode) ValidatorOperatorAddress(ctx context.Context) (string, error) {
+	command := []string{tn.Chain.Config().Bin, "keys", "show", "--address", valKey,
+		"--bech", "val",
+		"--home", tn.HomeDir(),
+		"--keyring-backend", keyring.BackendTest,
+	}
+
+	stdout, stderr, err := tn.Exec(ctx, command, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to show validator operator address (stderr=%q): %w", stderr, err)
+	}
+
+	return string(bytes.TrimSuffix(stdout, []byte("\n"))), nil
+}
+
+// Unjail submits a transaction to unjail the node's validator.
+// The validator's jail period must have passed and the node must be signing blocks again.
+func (tn *ChainNode) Unjail(ctx context.Context) error {
+	command := []string{tn.Chain.Config().Bin, "tx", "slashing", "unjail",
+		"--keyring-backend", keyring.BackendTest,
+		"--gas-prices", tn.Chain.Config().GasPrices,
+		"--gas-adjustment", fmt.Sprint(tn.Chain.Config().GasAdjustment),
+		"--node", fmt.Sprintf("tcp://%s:26657", tn.HostName()),
+		"--from", valKey,
+		"--output", "json",
+		"-y",
+		"--home", tn.HomeDir(),
+		"--chain-id", tn.Chain.Config().ChainID,
+	}
+	return tn.ExecThenWaitForBlocks(ctx, command)
+}
+
 // PeerString returns the string for connecting the nodes passed in
 func (nodes ChainNodes) PeerString(ctx context.Context) string {
 	addrs := make([]string, len(nodes))
@@ -931,6 +1359,49 @@ func (tn *ChainNode) QueryICA(ctx context.Context, connectionID, address string)
 	return strings.TrimSpace(parts[1]), nil
 }
 
+// SendICAMsg submits msg to be executed by the interchain account of fromAddr on the counterparty chain,
+// returning the hash of the submitted transaction.
+func (tn *ChainNode) SendICAMsg(ctx context.Context, connectionID, fromAddr string, msg types.Msg) (string, error) {
+	msgAny, err := codectypes.NewAnyWithValue(msg)
+	if err != nil {
+		return "", fmt.Errorf("pack %T: %w", msg, err)
+	}
+	// Without a resolver, the message type is looked up in the global registry rather than the chain's codec.
+	arg, err := codec.ProtoMarshalJSON(msgAny, nil)
+	if err != nil {
+		return "", fmt.Errorf("encode %T: %w", msg, err)
+	}
+
+	command := []string{tn.Chain.Config().Bin, "tx", "intertx", "submit", string(arg),
+		"--connection-id", connectionID,
+		"--from", fromAddr,
+		"--chain-id", tn.Chain.Config().ChainID,
+		"--home", tn.HomeDir(),
+		"--node", fmt.Sprintf("tcp://%s:26657", tn.Name()),
+		"--keyring-backend", keyring.BackendTest,
+		"--output", "json",
+		"-y",
+	}
+
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+	stdout, _, err := tn.Exec(ctx, command, nil)
+	if err != nil {
+		return "", err
+	}
+	var output CosmosTx
+	if err := json.Unmarshal(stdout, &output); err != nil {
+		return "", fmt.Errorf("parse intertx submit output: %w", err)
+	}
+	if output.Code != 0 {
+		return output.TxHash, fmt.Errorf("intertx submit transaction failed with code %d: %s", output.Code, output.RawLog)
+	}
+	if err := test.WaitForBlocks(ctx, 2, tn); err != nil {
+		return "", fmt.Errorf("wait for blocks: %w", err)
+	}
+	return output.TxHash, nil
+}
+
 // SendICABankTransfer builds a bank transfer message for a specified address and sends it to the specified
 // interchain account.
 func (tn *ChainNode) SendICABankTransfer(ctx context.Context, connectionID, fromAddr string, amount ibc.WalletAmount) error {
@@ -12,6 +12,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/types"
 	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	paramsutils "github.com/cosmos/cosmos-sdk/x/params/client/utils"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	chanTypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
 	dockertypes "github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
@@ -21,6 +25,7 @@ import (
 	"github.com/strangelove-ventures/ibctest/internal/blockdb"
 	"github.com/strangelove-ventures/ibctest/internal/dockerutil"
 	"github.com/strangelove-ventures/ibctest/test"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
@@ -34,6 +39,9 @@ type CosmosChain struct {
 	numFullNodes  int
 	ChainNodes    ChainNodes
 
+	// Nodes started by StartDuplicateValidator and StartFork, which are not part of ChainNodes.
+	duplicateValidators ChainNodes
+
 	log *zap.Logger
 }
 
@@ -86,6 +94,9 @@ func (c *CosmosChain) Initialize(testName string, _ string, cli *client.Client,
 	return c.initializeChainNodes(context.TODO(), testName, cli, networkID)
 }
 
+// getFullNode returns the node holding the chain's keyring, used for keys and transactions:
+// the first full node, or the first validator if the chain has no full nodes.
+// Keys exist only on this node, so key and transaction operations fail while it is stopped or paused.
 func (c *CosmosChain) getFullNode() *ChainNode {
 	if len(c.ChainNodes) > c.numValidators {
 		// use first full node
@@ -95,6 +106,33 @@ func (c *CosmosChain) getFullNode() *ChainNode {
 	return c.ChainNodes[0]
 }
 
+// getQueryNode returns the node to use for queries:
+// the first available full node, or else the first available validator.
+// If every node is stopped or paused, it returns the node returned by getFullNode.
+func (c *CosmosChain) getQueryNode() *ChainNode {
+	for _, n := range c.FullNodes() {
+		if n.IsAvailable() {
+			return n
+		}
+	}
+	for _, n := range c.Validators() {
+		if n.IsAvailable() {
+			return n
+		}
+	}
+	return c.getFullNode()
+}
+
+// Validators returns the chain's validator nodes.
+func (c *CosmosChain) Validators() ChainNodes {
+	return c.ChainNodes[:c.numValidators]
+}
+
+// FullNodes returns the chain's non-validator nodes.
+func (c *CosmosChain) FullNodes() ChainNodes {
+	return c.ChainNodes[c.numValidators:]
+}
+
 // Exec implements ibc.Chain.
 func (c *CosmosChain) Exec(ctx context.Context, cmd []string, env []string) (stdout, stderr []byte, err error) {
 	return c.getFullNode().Exec(ctx, cmd, env)
@@ -162,13 +200,24 @@ func (c *CosmosChain) SendIBCTransfer(ctx context.Context, channelID, keyName st
 	if err != nil {
 		return tx, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
 	}
+	if txResp.Code != 0 {
+		return tx, fmt.Errorf("ibc transfer transaction failed with code %d: %s", txResp.Code, txResp.RawLog)
+	}
 	tx.Height = uint64(txResp.Height)
 	tx.TxHash = txHash
 	// In cosmos, user is charged for entire gas requested, not the actual gas used.
 	tx.GasSpent = txResp.GasWanted
 
+	tx.Packet, err = sentPacket(txResp.Events)
+	if err != nil {
+		return tx, err
+	}
+	return tx, nil
+}
+
+// sentPacket returns the packet of the send_packet event among the events of a transaction.
+func sentPacket(events []abcitypes.Event) (ibc.Packet, error) {
 	const evType = "send_packet"
-	events := txResp.Events
 
 	var (
 		seq, _           = tendermint.AttributeValue(events, evType, "packet_sequence")
@@ -180,26 +229,28 @@ func (c *CosmosChain) SendIBCTransfer(ctx context.Context, channelID, keyName st
 		timeoutTs, _     = tendermint.AttributeValue(events, evType, "packet_timeout_timestamp")
 		data, _          = tendermint.AttributeValue(events, evType, "packet_data")
 	)
-	tx.Packet.SourcePort = srcPort
-	tx.Packet.SourceChannel = srcChan
-	tx.Packet.DestPort = dstPort
-	tx.Packet.DestChannel = dstChan
-	tx.Packet.TimeoutHeight = timeoutHeight
-	tx.Packet.Data = []byte(data)
+	packet := ibc.Packet{
+		SourcePort:    srcPort,
+		SourceChannel: srcChan,
+		DestPort:      dstPort,
+		DestChannel:   dstChan,
+		TimeoutHeight: timeoutHeight,
+		Data:          []byte(data),
+	}
 
 	seqNum, err := strconv.Atoi(seq)
 	if err != nil {
-		return tx, fmt.Errorf("invalid packet sequence from events %s: %w", seq, err)
+		return packet, fmt.Errorf("invalid packet sequence from events %s: %w", seq, err)
 	}
-	tx.Packet.Sequence = uint64(seqNum)
+	packet.Sequence = uint64(seqNum)
 
 	timeoutNano, err := strconv.ParseUint(timeoutTs, 10, 64)
 	if err != nil {
-		return tx, fmt.Errorf("invalid packet timestamp timeout %s: %w", timeoutTs, err)
+		return packet, fmt.Errorf("invalid packet timestamp timeout %s: %w", timeoutTs, err)
 	}
-	tx.Packet.TimeoutTimestamp = ibc.Nanoseconds(timeoutNano)
+	packet.TimeoutTimestamp = ibc.Nanoseconds(timeoutNano)
 
-	return tx, nil
+	return packet, nil
 }
 
 // Implements Chain interface
@@ -214,7 +265,7 @@ func (c *CosmosChain) ExecuteContract(ctx context.Context, keyName string, contr
 
 // Implements Chain interface
 func (c *CosmosChain) DumpContractState(ctx context.Context, contractAddress string, height int64) (*ibc.DumpContractStateResponse, error) {
-	return c.getFullNode().DumpContractState(ctx, contractAddress, height)
+	return c.getQueryNode().DumpContractState(ctx, contractAddress, height)
 }
 
 // Implements Chain interface
@@ -230,7 +281,7 @@ func (c *CosmosChain) CreatePool(ctx context.Context, keyName string, contractAd
 // Implements Chain interface
 func (c *CosmosChain) GetBalance(ctx context.Context, address string, denom string) (int64, error) {
 	params := &bankTypes.QueryBalanceRequest{Address: address, Denom: denom}
-	grpcAddress := c.getFullNode().hostGRPCPort
+	grpcAddress := c.getQueryNode().hostGRPCPort
 	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		return 0, err
@@ -252,7 +303,7 @@ func (c *CosmosChain) getTransaction(txHash string) (*types.TxResponse, error) {
 	var txResp *types.TxResponse
 	err := retry.Do(func() error {
 		var err error
-		txResp, err = authTx.QueryTx(c.getFullNode().CliContext(), txHash)
+		txResp, err = authTx.QueryTx(c.getQueryNode().CliContext(), txHash)
 		return err
 	}, retry.Attempts(15), retry.Delay(200*time.Millisecond)) // retry for total of 3 seconds
 	return txResp, err
@@ -274,21 +325,7 @@ func (c *CosmosChain) initializeChainNodes(
 	count := c.numValidators + c.numFullNodes
 	chainCfg := c.Config()
 	for _, image := range chainCfg.Images {
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			dockertypes.ImagePullOptions{},
-		)
-		if err != nil {
-			c.log.Error("Failed to pull image",
-				zap.Error(err),
-				zap.String("repository", image.Repository),
-				zap.String("tag", image.Version),
-			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
-		}
+		c.pullImage(ctx, cli, image)
 	}
 
 	image := chainCfg.Images[0]
@@ -297,45 +334,11 @@ func (c *CosmosChain) initializeChainNodes(
 	for i := 0; i < count; i++ {
 		i := i
 		eg.Go(func() error {
-			// Construct the ChainNode first so we can access its name.
-			// The ChainNode's VolumeName cannot be set until after we create the volume.
-			tn := &ChainNode{
-				log: c.log,
-
-				Index:        i,
-				Chain:        c,
-				DockerClient: cli,
-				NetworkID:    networkID,
-				TestName:     testName,
-				Image:        image,
-			}
-
-			v, err := cli.VolumeCreate(egCtx, volumetypes.VolumeCreateBody{
-				Labels: map[string]string{
-					dockerutil.CleanupLabel: testName,
-
-					dockerutil.NodeOwnerLabel: tn.Name(),
-				},
-			})
+			tn, err := c.newChainNode(egCtx, testName, cli, networkID, image, i, i < c.numValidators)
 			if err != nil {
-				return fmt.Errorf("creating volume for chain node: %w", err)
-			}
-			tn.VolumeName = v.Name
-
-			if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
-				Log: c.log,
-
-				Client: cli,
-
-				VolumeName: v.Name,
-				ImageRef:   image.Ref(),
-				TestName:   testName,
-			}); err != nil {
-				return fmt.Errorf("set volume owner: %w", err)
+				return err
 			}
-
 			chainNodes[i] = tn
-
 			return nil
 		})
 	}
@@ -346,6 +349,77 @@ func (c *CosmosChain) initializeChainNodes(
 	return nil
 }
 
+// newChainNode constructs a ChainNode with a new volume, ready to be initialized.
+func (c *CosmosChain) newChainNode(
+	ctx context.Context,
+	testName string,
+	cli *client.Client,
+	networkID string,
+	image ibc.DockerImage,
+	index int,
+	validator bool,
+) (*ChainNode, error) {
+	// Construct the ChainNode first so we can access its name.
+	// The ChainNode's VolumeName cannot be set until after we create the volume.
+	tn := &ChainNode{
+		log: c.log,
+
+		Index:        index,
+		Chain:        c,
+		Validator:    validator,
+		DockerClient: cli,
+		NetworkID:    networkID,
+		TestName:     testName,
+		Image:        image,
+	}
+
+	v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
+		Labels: map[string]string{
+			dockerutil.CleanupLabel: testName,
+
+			dockerutil.NodeOwnerLabel: tn.Name(),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating volume for chain node: %w", err)
+	}
+	tn.VolumeName = v.Name
+
+	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
+		Log: c.log,
+
+		Client: cli,
+
+		VolumeName: v.Name,
+		ImageRef:   image.Ref(),
+		TestName:   testName,
+	}); err != nil {
+		return nil, fmt.Errorf("set volume owner: %w", err)
+	}
+
+	return tn, nil
+}
+
+// pullImage pulls image, logging rather than returning any error
+// so that locally built images can still be used.
+func (c *CosmosChain) pullImage(ctx context.Context, cli *client.Client, image ibc.DockerImage) {
+	rc, err := cli.ImagePull(
+		ctx,
+		image.Repository+":"+image.Version,
+		dockertypes.ImagePullOptions{},
+	)
+	if err != nil {
+		c.log.Error("Failed to pull image",
+			zap.Error(err),
+			zap.String("repository", image.Repository),
+			zap.String("tag", image.Version),
+		)
+		return
+	}
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
+}
+
 type GenesisValidatorPubKey struct {
 	Type  string `json:"type"`
 	Value string `json:"value"`
@@ -370,37 +444,45 @@ type ValidatorWithIntPower struct {
 func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGenesisWallets ...ibc.WalletAmount) error {
 	chainCfg := c.Config()
 
-	genesisAmount := types.Coin{
-		Amount: types.NewInt(1000000000000),
-		Denom:  chainCfg.Denom,
+	validators := c.Validators()
+
+	eg := new(errgroup.Group)
+	for _, n := range c.ChainNodes {
+		n := n
+		eg.Go(func() error { return n.InitHomeFolder(ctx) })
+	}
+	if err := eg.Wait(); err != nil {
+		return err
 	}
 
-	genesisStakeAmount := types.Coin{
+	// The self-delegations must be made in the bond denom of the final genesis,
+	// which may have been changed by ModifyGenesis.
+	bondDenom, err := c.bondDenom(ctx, validators[0])
+	if err != nil {
+		return err
+	}
+
+	genesisAmounts := []types.Coin{{
 		Amount: types.NewInt(1000000000000),
-		Denom:  "stake",
+		Denom:  chainCfg.Denom,
+	}}
+	if bondDenom != chainCfg.Denom {
+		genesisAmounts = append(genesisAmounts, types.Coin{
+			Amount: types.NewInt(1000000000000),
+			Denom:  bondDenom,
+		})
 	}
 
 	genesisSelfDelegation := types.Coin{
 		Amount: types.NewInt(100000000000),
-		Denom:  "stake",
+		Denom:  bondDenom,
 	}
 
-	genesisAmounts := []types.Coin{genesisAmount, genesisStakeAmount}
-
-	validators := c.ChainNodes[:c.numValidators]
-	fullnodes := c.ChainNodes[c.numValidators:]
-
-	eg := new(errgroup.Group)
+	eg = new(errgroup.Group)
 	// sign gentx for each validator
 	for _, v := range validators {
 		v := v
-		eg.Go(func() error { return v.InitValidatorFiles(ctx, &chainCfg, genesisAmounts, genesisSelfDelegation) })
-	}
-
-	// just initialize folder for any full nodes
-	for _, n := range fullnodes {
-		n := n
-		eg.Go(func() error { return n.InitFullNodeFiles(ctx) })
+		eg.Go(func() error { return v.InitValidatorGenTx(ctx, &chainCfg, genesisAmounts, genesisSelfDelegation) })
 	}
 
 	// wait for this to finish
@@ -443,7 +525,17 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		return err
 	}
 
-	for _, cn := range c.ChainNodes[1:] {
+	// Only the other nodes need the genesis file, unless it is modified here.
+	genesisNodes := c.ChainNodes[1:]
+	if c.cfg.ModifyGenesis != nil {
+		genbz, err = c.cfg.ModifyGenesis(chainCfg, genbz)
+		if err != nil {
+			return fmt.Errorf("modify genesis: %w", err)
+		}
+		genesisNodes = c.ChainNodes
+	}
+
+	for _, cn := range genesisNodes {
 		if err := cn.overwriteGenesisFile(ctx, genbz); err != nil {
 			return err
 		}
@@ -453,6 +545,20 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		return err
 	}
 
+	return c.startNodes(ctx)
+}
+
+// StartRestoredNodes starts the chain from node volumes restored from a snapshot of another chain
+// with the same configuration and number of nodes, such as one taken by an ibctest.Interchain.
+// Since the peers of each node depend on the name of the test, their configuration is rewritten
+// before a container is created and started for every node.
+func (c *CosmosChain) StartRestoredNodes(ctx context.Context) error {
+	return c.startNodes(ctx)
+}
+
+// startNodes creates and starts a container for every node against its initialized volume,
+// with the other nodes as its peers, and waits for the chain to produce blocks.
+func (c *CosmosChain) startNodes(ctx context.Context) error {
 	eg, egCtx := errgroup.WithContext(ctx)
 	for _, n := range c.ChainNodes {
 		n := n
@@ -485,9 +591,440 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 	return test.WaitForBlocks(ctx, 5, c.getFullNode())
 }
 
+// TextProposal submits a text governance proposal signed by keyName,
+// returning the ID of the new proposal.
+func (c *CosmosChain) TextProposal(ctx context.Context, keyName string, prop TextProposal) (string, error) {
+	txHash, err := c.getFullNode().TextProposal(ctx, keyName, prop)
+	if err != nil {
+		return "", fmt.Errorf("submit text proposal: %w", err)
+	}
+	return c.txProposalID(txHash)
+}
+
+// ParamChangeProposal submits a parameter change governance proposal signed by keyName,
+// returning the ID of the new proposal.
+func (c *CosmosChain) ParamChangeProposal(ctx context.Context, keyName string, prop paramsutils.ParamChangeProposalJSON) (string, error) {
+	txHash, err := c.getFullNode().ParamChangeProposal(ctx, keyName, prop)
+	if err != nil {
+		return "", fmt.Errorf("submit param change proposal: %w", err)
+	}
+	return c.txProposalID(txHash)
+}
+
+// ClientUpdateProposal submits a governance proposal to substitute an IBC client, signed by keyName,
+// returning the ID of the new proposal.
+func (c *CosmosChain) ClientUpdateProposal(ctx context.Context, keyName string, prop ClientUpdateProposal) (string, error) {
+	txHash, err := c.getFullNode().ClientUpdateProposal(ctx, keyName, prop)
+	if err != nil {
+		return "", fmt.Errorf("submit client update proposal: %w", err)
+	}
+	return c.txProposalID(txHash)
+}
+
+// UpgradeProposal submits a software-upgrade governance proposal signed by keyName,
+// returning the ID of the new proposal.
+func (c *CosmosChain) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (string, error) {
+	txHash, err := c.getFullNode().UpgradeProposal(ctx, keyName, prop)
+	if err != nil {
+		return "", fmt.Errorf("submit upgrade proposal: %w", err)
+	}
+	return c.txProposalID(txHash)
+}
+
+// txProposalID returns the ID of the proposal submitted by the transaction with the given hash.
+func (c *CosmosChain) txProposalID(txHash string) (string, error) {
+	txResp, err := c.getTransaction(txHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	proposalID, ok := tendermint.AttributeValue(txResp.Events, "submit_proposal", "proposal_id")
+	if !ok {
+		return "", fmt.Errorf("proposal id not found in events of transaction %s", txHash)
+	}
+	return proposalID, nil
+}
+
+// DepositOnProposal deposits amount, e.g. "10000000stake", from keyName on a governance proposal.
+func (c *CosmosChain) DepositOnProposal(ctx context.Context, keyName string, proposalID string, amount string) error {
+	return c.getFullNode().DepositOnProposal(ctx, keyName, proposalID, amount)
+}
+
+// VoteOnProposalAllValidators casts the same vote on a governance proposal from every validator.
+// Vote must be one of the ProposalVote constants.
+func (c *CosmosChain) VoteOnProposalAllValidators(ctx context.Context, proposalID string, vote string) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range c.Validators() {
+		n := n
+		eg.Go(func() error {
+			return n.VoteOnProposal(egCtx, valKey, proposalID, vote)
+		})
+	}
+	return eg.Wait()
+}
+
+// QueryProposal returns the current state of a governance proposal.
+func (c *CosmosChain) QueryProposal(ctx context.Context, proposalID string) (*govtypes.Proposal, error) {
+	id, err := strconv.ParseUint(proposalID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal id %q: %w", proposalID, err)
+	}
+
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := govtypes.NewQueryClient(conn)
+	res, err := queryClient.Proposal(ctx, &govtypes.QueryProposalRequest{ProposalId: id})
+	if err != nil {
+		return nil, err
+	}
+	return &res.Proposal, nil
+}
+
+// PollForProposalStatus blocks until a governance proposal leaves its deposit and voting periods,
+// returning the proposal in its final state.
+// The status of the returned proposal is one of passed, rejected or failed.
+func (c *CosmosChain) PollForProposalStatus(ctx context.Context, proposalID string) (*govtypes.Proposal, error) {
+	for {
+		prop, err := c.QueryProposal(ctx, proposalID)
+		if err != nil {
+			return nil, fmt.Errorf("query proposal %s: %w", proposalID, err)
+		}
+		switch prop.Status {
+		case govtypes.StatusPassed, govtypes.StatusRejected, govtypes.StatusFailed:
+			return prop, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("proposal %s still in status %s: %w", proposalID, prop.Status, ctx.Err())
+		case <-time.After(blockTime * time.Second):
+		}
+	}
+}
+
+// StopAllNodes stops and removes every node's container, leaving the node volumes intact.
+func (c *CosmosChain) StopAllNodes(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range c.ChainNodes {
+		n := n
+		eg.Go(func() error {
+			if err := n.StopContainer(egCtx); err != nil {
+				return fmt.Errorf("stop container %s: %w", n.Name(), err)
+			}
+			if err := n.RemoveContainer(egCtx); err != nil {
+				return fmt.Errorf("remove container %s: %w", n.Name(), err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// StartAllNodes creates and starts a container for every node against its existing volume,
+// then waits for the chain to produce blocks.
+// The nodes' containers must have been removed first, e.g. by StopAllNodes.
+func (c *CosmosChain) StartAllNodes(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range c.ChainNodes {
+		n := n
+		c.log.Info("Starting container", zap.String("container", n.Name()))
+		eg.Go(func() error {
+			if err := n.CreateNodeContainer(egCtx); err != nil {
+				return err
+			}
+			return n.StartContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	return test.WaitForBlocks(ctx, 5, c.getQueryNode())
+}
+
+// UpgradeVersion sets every node's image to the image in Config().Images with the given version.
+// The new image takes effect the next time the nodes' containers are created.
+func (c *CosmosChain) UpgradeVersion(version string) error {
+	image, ok := c.imageVersion(version)
+	if !ok {
+		return fmt.Errorf("no image with version %s configured for chain %s", version, c.cfg.ChainID)
+	}
+	for _, n := range c.ChainNodes {
+		n.Image = image
+	}
+	return nil
+}
+
+// imageVersion returns the image in Config().Images with the given version.
+func (c *CosmosChain) imageVersion(version string) (ibc.DockerImage, bool) {
+	for _, img := range c.cfg.Images {
+		if img.Version == version {
+			return img, true
+		}
+	}
+	return ibc.DockerImage{}, false
+}
+
+// Upgrade performs a software upgrade of the chain to the image with the given version,
+// which must be the version of one of the images in Config().Images.
+//
+// It submits prop as a software-upgrade governance proposal from keyName,
+// votes yes with every validator, and waits for all nodes to halt at prop.Height.
+// The nodes are then stopped and recreated with the new image against their existing volumes.
+//
+// The proposal must pass before the chain reaches prop.Height,
+// so the chain's genesis must have a voting period shorter than the time to reach that height;
+// see ModifyGenesisProposalTime.
+func (c *CosmosChain) Upgrade(ctx context.Context, keyName string, prop SoftwareUpgradeProposal, version string) error {
+	// Check the version before scheduling the halt, as the chain cannot continue without the new image.
+	if _, ok := c.imageVersion(version); !ok {
+		return fmt.Errorf("no image with version %s configured for chain %s", version, c.cfg.ChainID)
+	}
+	proposalID, err := c.UpgradeProposal(ctx, keyName, prop)
+	if err != nil {
+		return err
+	}
+	if err := c.VoteOnProposalAllValidators(ctx, proposalID, ProposalVoteYes); err != nil {
+		return fmt.Errorf("vote on upgrade proposal %s: %w", proposalID, err)
+	}
+	if err := c.waitForHalt(ctx, prop.Height); err != nil {
+		return err
+	}
+
+	c.log.Info("Upgrading chain",
+		zap.String("chain_id", c.cfg.ChainID),
+		zap.String("upgrade_name", prop.Name),
+		zap.Uint64("halt_height", prop.Height),
+		zap.String("version", version),
+	)
+	if err := c.StopAllNodes(ctx); err != nil {
+		return err
+	}
+	if err := c.UpgradeVersion(version); err != nil {
+		return err
+	}
+	return c.StartAllNodes(ctx)
+}
+
+// haltConfirmPolls is the number of consecutive polls at which a node must report the upgrade halt height
+// for it to be considered halted.
+const haltConfirmPolls = 3
+
+// waitForHalt blocks until every node has halted at haltHeight for an upgrade,
+// returning an error if any node goes past haltHeight.
+func (c *CosmosChain) waitForHalt(ctx context.Context, haltHeight uint64) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range c.ChainNodes {
+		n := n
+		eg.Go(func() error {
+			if err := waitForHeightHalt(egCtx, n.Height, haltHeight, blockTime*time.Second); err != nil {
+				return fmt.Errorf("node %s: %w", n.Name(), err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// waitForHeightHalt polls height every interval until it reports haltHeight for haltConfirmPolls consecutive polls.
+// If the proposal scheduling the upgrade did not pass, the height goes past haltHeight and an error is returned.
+func waitForHeightHalt(ctx context.Context, height func(context.Context) (uint64, error), haltHeight uint64, interval time.Duration) error {
+	polls := 0
+	for {
+		h, err := height(ctx)
+		if err != nil {
+			return fmt.Errorf("height: %w", err)
+		}
+		switch {
+		case h > haltHeight:
+			return fmt.Errorf("reached height %d, past upgrade halt height %d; the upgrade proposal may not have passed", h, haltHeight)
+		case h == haltHeight:
+			polls++
+			if polls == haltConfirmPolls {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// QueryValidator returns the staking module's state of the validator with the given operator address.
+func (c *CosmosChain) QueryValidator(ctx context.Context, operatorAddress string) (*stakingtypes.Validator, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := stakingtypes.NewQueryClient(conn)
+	res, err := queryClient.Validator(ctx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: operatorAddress})
+	if err != nil {
+		return nil, err
+	}
+	return &res.Validator, nil
+}
+
+// QueryBondDenom returns the denom that the staking module of the running chain bonds.
+func (c *CosmosChain) QueryBondDenom(ctx context.Context) (string, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	queryClient := stakingtypes.NewQueryClient(conn)
+	res, err := queryClient.Params(ctx, &stakingtypes.QueryParamsRequest{})
+	if err != nil {
+		return "", err
+	}
+	return res.Params.BondDenom, nil
+}
+
+// QuerySigningInfo returns the slashing module's signing info of the validator with the given consensus address.
+func (c *CosmosChain) QuerySigningInfo(ctx context.Context, consensusAddress string) (*slashingtypes.ValidatorSigningInfo, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := slashingtypes.NewQueryClient(conn)
+	res, err := queryClient.SigningInfo(ctx, &slashingtypes.QuerySigningInfoRequest{ConsAddress: consensusAddress})
+	if err != nil {
+		return nil, err
+	}
+	return &res.ValSigningInfo, nil
+}
+
+// WaitForValidatorJailed blocks until the validator with the given operator address is jailed,
+// returning the validator's jailed state.
+func (c *CosmosChain) WaitForValidatorJailed(ctx context.Context, operatorAddress string) (*stakingtypes.Validator, error) {
+	for {
+		val, err := c.QueryValidator(ctx, operatorAddress)
+		if err != nil {
+			return nil, fmt.Errorf("query validator %s: %w", operatorAddress, err)
+		}
+		if val.Jailed {
+			return val, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("validator %s not jailed: %w", operatorAddress, ctx.Err())
+		case <-time.After(blockTime * time.Second):
+		}
+	}
+}
+
+// StopValidatorUntilJailed stops the container of the validator at index in Validators(),
+// and blocks until the validator is jailed for missing blocks.
+// The validator is left stopped; restart it with StartContainer, then unjail it with Unjail.
+//
+// With the default slashing parameters, the validator must miss more than half of a 100 block window,
+// which takes several minutes; see ModifyGenesisSignedBlocksWindow to shorten it.
+// Stopping a validator holding more than a third of the voting power halts the chain,
+// in which case the validator is never jailed.
+func (c *CosmosChain) StopValidatorUntilJailed(ctx context.Context, index int) error {
+	val := c.Validators()[index]
+	operatorAddress, err := val.ValidatorOperatorAddress(ctx)
+	if err != nil {
+		return err
+	}
+	if err := val.StopContainer(ctx); err != nil {
+		return fmt.Errorf("stop validator %s: %w", val.Name(), err)
+	}
+	_, err = c.WaitForValidatorJailed(ctx, operatorAddress)
+	return err
+}
+
+// StartDuplicateValidator starts a new node signing with the same private validator key
+// as the validator at index in Validators(), so that the validator double-signs.
+// Once the duplicate catches up with the chain, the two nodes cast conflicting votes,
+// which are committed as equivocation evidence, jailing and tombstoning the validator.
+// Use WaitForValidatorJailed and QuerySigningInfo to observe the result.
+//
+// The duplicate node is not included in ChainNodes.
+// Stop it with StopContainer once the validator is jailed.
+func (c *CosmosChain) StartDuplicateValidator(ctx context.Context, index int) (*ChainNode, error) {
+	val := c.Validators()[index]
+
+	dupIndex := len(c.ChainNodes) + len(c.duplicateValidators)
+	dup, err := c.newChainNode(ctx, val.TestName, val.DockerClient, val.NetworkID, val.Image, dupIndex, true)
+	if err != nil {
+		return nil, err
+	}
+	c.duplicateValidators = append(c.duplicateValidators, dup)
+
+	if err := dup.InitHomeFolder(ctx); err != nil {
+		return nil, err
+	}
+
+	genbz, err := val.genesisFileContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := dup.overwriteGenesisFile(ctx, genbz); err != nil {
+		return nil, err
+	}
+
+	key, err := val.privValidatorKeyContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := dup.overwritePrivValidatorKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	if err := dup.SetValidatorConfigAndPeers(ctx, c.ChainNodes.PeerString(ctx)); err != nil {
+		return nil, err
+	}
+	if err := dup.CreateNodeContainer(ctx); err != nil {
+		return nil, err
+	}
+
+	c.log.Info("Starting duplicate validator",
+		zap.String("container", dup.Name()),
+		zap.String("validator", val.Name()),
+	)
+	if err := dup.StartContainer(ctx); err != nil {
+		return nil, err
+	}
+	return dup, nil
+}
+
+// bondDenom returns the staking bond denom of the chain's genesis,
+// after any ModifyGenesis function is applied to the initial genesis of node.
+func (c *CosmosChain) bondDenom(ctx context.Context, node *ChainNode) (string, error) {
+	genbz, err := node.genesisFileContent(ctx)
+	if err != nil {
+		return "", err
+	}
+	if c.cfg.ModifyGenesis != nil {
+		genbz, err = c.cfg.ModifyGenesis(c.cfg, genbz)
+		if err != nil {
+			return "", fmt.Errorf("modify genesis: %w", err)
+		}
+	}
+	denom, err := genesisBondDenom(genbz)
+	if err != nil {
+		c.log.Info("Using default bond denom", zap.String("chain_id", c.cfg.ChainID), zap.Error(err))
+		return defaultBondDenom, nil
+	}
+	return denom, nil
+}
+
 // Height implements ibc.Chain
 func (c *CosmosChain) Height(ctx context.Context) (uint64, error) {
-	return c.getFullNode().Height(ctx)
+	return c.getQueryNode().Height(ctx)
 }
 
 // RegisterInterchainAccount will register an interchain account on behalf of the calling chain (controller chain)
@@ -503,13 +1040,58 @@ func (c *CosmosChain) SendICABankTransfer(ctx context.Context, connectionID, fro
 
 // QueryInterchainAccount will query the interchain account that was created on behalf of the specified address.
 func (c *CosmosChain) QueryInterchainAccount(ctx context.Context, connectionID, address string) (string, error) {
-	return c.getFullNode().QueryICA(ctx, connectionID, address)
+	return c.getQueryNode().QueryICA(ctx, connectionID, address)
+}
+
+// SendICAMsg implements ibc.Chain, submitting msg with the intertx module of the controller chain,
+// which sends a single message per packet.
+// The message type must be registered with the gogoproto registry, as generated Cosmos SDK messages are,
+// but need not be known to the chain's codec.
+func (c *CosmosChain) SendICAMsg(ctx context.Context, connectionID, fromAddr string, msg types.Msg, maxBlocks uint64) (ibc.ICAResult, error) {
+	txHash, err := c.getFullNode().SendICAMsg(ctx, connectionID, fromAddr, msg)
+	if err != nil {
+		return ibc.ICAResult{}, fmt.Errorf("send ica msg: %w", err)
+	}
+	txResp, err := c.getTransaction(txHash)
+	if err != nil {
+		return ibc.ICAResult{}, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	if txResp.Code != 0 {
+		return ibc.ICAResult{}, fmt.Errorf("ica transaction failed with code %d: %s", txResp.Code, txResp.RawLog)
+	}
+
+	packet, err := sentPacket(txResp.Events)
+	if err != nil {
+		return ibc.ICAResult{}, err
+	}
+	result := ibc.ICAResult{Packet: packet}
+
+	height := uint64(txResp.Height)
+	ack, _, err := test.PollForAckOrTimeout(ctx, c, height, height+maxBlocks, packet)
+	if err != nil {
+		return result, fmt.Errorf("ica packet %d on %s not relayed: %w", packet.Sequence, packet.SourceChannel, err)
+	}
+	if ack == nil {
+		result.TimedOut = true
+		return result, nil
+	}
+
+	var chanAck chanTypes.Acknowledgement
+	if err := chanTypes.SubModuleCdc.UnmarshalJSON(ack.Acknowledgement, &chanAck); err != nil {
+		return result, fmt.Errorf("decode acknowledgement of ica packet %d: %w", packet.Sequence, err)
+	}
+	if chanAck.Success() {
+		result.Result = chanAck.GetResult()
+	} else {
+		result.Error = chanAck.GetError()
+	}
+	return result, nil
 }
 
 // Acknowledgements implements ibc.Chain, returning all acknowledgments in block at height
 func (c *CosmosChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
 	var acks []*chanTypes.MsgAcknowledgement
-	err := rangeBlockMessages(ctx, c.getFullNode().Client, height, func(msg types.Msg) bool {
+	err := rangeBlockMessages(ctx, c.getQueryNode().Client, height, func(msg types.Msg) bool {
 		found, ok := msg.(*chanTypes.MsgAcknowledgement)
 		if ok {
 			acks = append(acks, found)
@@ -542,7 +1124,7 @@ func (c *CosmosChain) Acknowledgements(ctx context.Context, height uint64) ([]ib
 // Timeouts implements ibc.Chain, returning all timeouts in block at height
 func (c *CosmosChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
 	var timeouts []*chanTypes.MsgTimeout
-	err := rangeBlockMessages(ctx, c.getFullNode().Client, height, func(msg types.Msg) bool {
+	err := rangeBlockMessages(ctx, c.getQueryNode().Client, height, func(msg types.Msg) bool {
 		found, ok := msg.(*chanTypes.MsgTimeout)
 		if ok {
 			timeouts = append(timeouts, found)
@@ -573,5 +1155,5 @@ func (c *CosmosChain) Timeouts(ctx context.Context, height uint64) ([]ibc.Packet
 
 // FindTxs implements blockdb.BlockSaver.
 func (c *CosmosChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
-	return c.getFullNode().FindTxs(ctx, height)
+	return c.getQueryNode().FindTxs(ctx, height)
 }
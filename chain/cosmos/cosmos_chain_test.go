@@ -0,0 +1,133 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmosChain_getQueryNode(t *testing.T) {
+	val0, val1, full0, full1 := &ChainNode{Index: 0}, &ChainNode{Index: 1}, &ChainNode{Index: 2}, &ChainNode{Index: 3}
+	c := &CosmosChain{
+		numValidators: 2,
+		numFullNodes:  2,
+		ChainNodes:    ChainNodes{val0, val1, full0, full1},
+	}
+
+	require.Same(t, full0, c.getQueryNode())
+
+	full0.setState(true, false)
+	require.Same(t, full1, c.getQueryNode())
+
+	full1.setState(false, true)
+	require.Same(t, val0, c.getQueryNode())
+
+	val0.setState(true, false)
+	require.Same(t, val1, c.getQueryNode())
+
+	// With every node unavailable, fall back to the first full node.
+	val1.setState(false, true)
+	require.Same(t, full0, c.getQueryNode())
+
+	full1.setState(false, false)
+	require.Same(t, full1, c.getQueryNode())
+}
+
+func TestCosmosChain_getQueryNode_NoFullNodes(t *testing.T) {
+	val0, val1 := &ChainNode{Index: 0}, &ChainNode{Index: 1}
+	c := &CosmosChain{
+		numValidators: 2,
+		ChainNodes:    ChainNodes{val0, val1},
+	}
+
+	require.Same(t, val0, c.getQueryNode())
+
+	val0.setState(true, false)
+	require.Same(t, val1, c.getQueryNode())
+
+	val1.setState(true, false)
+	require.Same(t, val0, c.getQueryNode())
+}
+
+func TestCosmosChain_getFullNode(t *testing.T) {
+	val0, full0, full1 := &ChainNode{Index: 0}, &ChainNode{Index: 1}, &ChainNode{Index: 2}
+	c := &CosmosChain{
+		numValidators: 1,
+		numFullNodes:  2,
+		ChainNodes:    ChainNodes{val0, full0, full1},
+	}
+
+	// Keys and transactions stay on the node holding the keyring, even while it is unavailable.
+	require.Same(t, full0, c.getFullNode())
+	full0.setState(true, false)
+	require.Same(t, full0, c.getFullNode())
+	require.Same(t, full1, c.getQueryNode())
+
+	noFullNodes := &CosmosChain{
+		numValidators: 1,
+		ChainNodes:    ChainNodes{val0},
+	}
+	require.Same(t, val0, noFullNodes.getFullNode())
+}
+
+func TestWaitForHeightHalt(t *testing.T) {
+	const haltHeight = 10
+
+	// heights returns a height function reporting each of hs in turn, then the last one repeatedly.
+	heights := func(hs ...uint64) func(context.Context) (uint64, error) {
+		i := 0
+		return func(context.Context) (uint64, error) {
+			h := hs[i]
+			if i < len(hs)-1 {
+				i++
+			}
+			return h, nil
+		}
+	}
+
+	t.Run("halted at halt height", func(t *testing.T) {
+		err := waitForHeightHalt(context.Background(), heights(8, 9, 10), haltHeight, time.Millisecond)
+		require.NoError(t, err)
+	})
+
+	t.Run("past halt height", func(t *testing.T) {
+		err := waitForHeightHalt(context.Background(), heights(9, 10, 11), haltHeight, time.Millisecond)
+		require.ErrorContains(t, err, "past upgrade halt height 10")
+	})
+
+	t.Run("height error", func(t *testing.T) {
+		err := waitForHeightHalt(context.Background(), func(context.Context) (uint64, error) {
+			return 0, errors.New("connection refused")
+		}, haltHeight, time.Millisecond)
+		require.ErrorContains(t, err, "connection refused")
+	})
+
+	t.Run("context canceled before halt", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := waitForHeightHalt(ctx, heights(9), haltHeight, time.Millisecond)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestCosmosChain_UpgradeVersion(t *testing.T) {
+	v1 := ibc.DockerImage{Repository: "ghcr.io/strangelove-ventures/heighliner/juno", Version: "v6.0.0"}
+	v2 := ibc.DockerImage{Repository: "ghcr.io/strangelove-ventures/heighliner/juno", Version: "v8.0.0"}
+	n0, n1 := &ChainNode{Image: v1}, &ChainNode{Image: v1}
+	c := &CosmosChain{
+		cfg:        ibc.ChainConfig{ChainID: "juno-1", Images: []ibc.DockerImage{v1, v2}},
+		ChainNodes: ChainNodes{n0, n1},
+	}
+
+	require.NoError(t, c.UpgradeVersion("v8.0.0"))
+	require.Equal(t, v2, n0.Image)
+	require.Equal(t, v2, n1.Image)
+
+	// A version without a configured image is rejected, leaving the nodes' images unchanged.
+	require.ErrorContains(t, c.UpgradeVersion("v9.0.0"), "no image with version v9.0.0")
+	require.Equal(t, v2, n0.Image)
+}
@@ -0,0 +1,90 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/ibctest/test"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// StartFork starts a fork of the chain: a new set of validator nodes,
+// one for each of the chain's validators and signing with the same private validator key,
+// that produce blocks from the chain's genesis while peering only with each other.
+//
+// The fork shares the chain's ID and validator set, but its blocks differ from the chain's blocks
+// at the same heights, so headers of the fork conflict with headers of the chain.
+// Because the fork starts from genesis, it reaches a given height later than the chain.
+//
+// The returned chain only has validator nodes.
+// Its nodes are not included in the ChainNodes of c; stop them with StopAllNodes.
+func (c *CosmosChain) StartFork(ctx context.Context) (*CosmosChain, error) {
+	vals := c.Validators()
+
+	fork := &CosmosChain{
+		testName:      c.testName,
+		cfg:           c.cfg,
+		numValidators: len(vals),
+		log:           c.log.With(zap.Bool("fork", true)),
+	}
+
+	genbz, err := vals[0].genesisFileContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, val := range vals {
+		// Fork nodes need names distinct from the chain's nodes, which share the chain ID.
+		index := len(c.ChainNodes) + len(c.duplicateValidators)
+		node, err := fork.newChainNode(ctx, val.TestName, val.DockerClient, val.NetworkID, val.Image, index, true)
+		if err != nil {
+			return nil, err
+		}
+		c.duplicateValidators = append(c.duplicateValidators, node)
+		fork.ChainNodes = append(fork.ChainNodes, node)
+
+		// The new home folder has a fresh private validator state,
+		// so the fork's validators sign from the genesis height.
+		if err := node.InitHomeFolder(ctx); err != nil {
+			return nil, err
+		}
+		if err := node.overwriteGenesisFile(ctx, genbz); err != nil {
+			return nil, err
+		}
+		key, err := val.privValidatorKeyContent(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := node.overwritePrivValidatorKey(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+
+	peers := fork.ChainNodes.PeerString(ctx)
+
+	var eg errgroup.Group
+	for _, n := range fork.ChainNodes {
+		n := n
+		eg.Go(func() error {
+			if err := n.SetValidatorConfigAndPeers(ctx, peers); err != nil {
+				return err
+			}
+			if err := n.CreateNodeContainer(ctx); err != nil {
+				return err
+			}
+			return n.StartContainer(ctx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, fmt.Errorf("start fork of %s: %w", c.cfg.ChainID, err)
+	}
+
+	fork.log.Info("Started fork", zap.String("chain_id", c.cfg.ChainID))
+
+	// Wait for the fork to produce blocks before returning.
+	if err := test.WaitForBlocks(ctx, 2, fork.getQueryNode()); err != nil {
+		return nil, fmt.Errorf("wait for fork of %s: %w", c.cfg.ChainID, err)
+	}
+	return fork, nil
+}
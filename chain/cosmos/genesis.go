@@ -0,0 +1,185 @@
+package cosmos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest/ibc"
+)
+
+// defaultBondDenom is the staking bond denom in the genesis generated by "init",
+// unless a chain's binary overrides it.
+const defaultBondDenom = "stake"
+
+// ModifyGenesisSequence returns a ModifyGenesis function for ibc.ChainConfig
+// that applies each of fns in order, passing the output of each to the next.
+func ModifyGenesisSequence(fns ...func(ibc.ChainConfig, []byte) ([]byte, error)) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(chainConfig ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		for _, fn := range fns {
+			var err error
+			genbz, err = fn(chainConfig, genbz)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return genbz, nil
+	}
+}
+
+// ModifyGenesisProposalTime returns a ModifyGenesis function for ibc.ChainConfig
+// that sets the gov module's voting period and maximum deposit period, e.g. "10s",
+// so that governance proposals can complete within a test.
+func ModifyGenesisProposalTime(votingPeriod, maxDepositPeriod string) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesis(func(g map[string]interface{}) error {
+		if err := setGenesisValue(g, votingPeriod, "app_state", "gov", "voting_params", "voting_period"); err != nil {
+			return err
+		}
+		return setGenesisValue(g, maxDepositPeriod, "app_state", "gov", "deposit_params", "max_deposit_period")
+	})
+}
+
+// ModifyGenesisSignedBlocksWindow returns a ModifyGenesis function for ibc.ChainConfig
+// that sets the slashing module's window of blocks used to detect downtime,
+// and the minimum proportion of those blocks a validator must sign, e.g. "0.5",
+// so that an offline validator is jailed within a test.
+func ModifyGenesisSignedBlocksWindow(signedBlocksWindow uint64, minSignedPerWindow string) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesis(func(g map[string]interface{}) error {
+		// The window is an int64, which is encoded as a JSON string.
+		if err := setGenesisValue(g, strconv.FormatUint(signedBlocksWindow, 10), "app_state", "slashing", "params", "signed_blocks_window"); err != nil {
+			return err
+		}
+		return setGenesisValue(g, minSignedPerWindow, "app_state", "slashing", "params", "min_signed_per_window")
+	})
+}
+
+// ModifyGenesisStakingBondDenom returns a ModifyGenesis function for ibc.ChainConfig
+// that sets the staking module's bond denom.
+// The mint denom, crisis constant fee denom and gov minimum deposit denom are set to match,
+// for those modules present in the genesis.
+//
+// The validators' genesis self-delegations are made in the bond denom set here.
+func ModifyGenesisStakingBondDenom(denom string) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesis(func(g map[string]interface{}) error {
+		if err := setGenesisValue(g, denom, "app_state", "staking", "params", "bond_denom"); err != nil {
+			return err
+		}
+		if hasGenesisObject(g, "app_state", "mint", "params") {
+			if err := setGenesisValue(g, denom, "app_state", "mint", "params", "mint_denom"); err != nil {
+				return err
+			}
+		}
+		if hasGenesisObject(g, "app_state", "crisis", "constant_fee") {
+			if err := setGenesisValue(g, denom, "app_state", "crisis", "constant_fee", "denom"); err != nil {
+				return err
+			}
+		}
+		if hasGenesisObject(g, "app_state", "gov", "deposit_params") {
+			deposits, _ := getGenesisValue(g, "app_state", "gov", "deposit_params", "min_deposit").([]interface{})
+			for _, d := range deposits {
+				if coin, ok := d.(map[string]interface{}); ok {
+					coin["denom"] = denom
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// ModifyGenesisAllowedClients returns a ModifyGenesis function for ibc.ChainConfig
+// that sets the IBC client types, e.g. "07-tendermint", the chain allows to be created.
+func ModifyGenesisAllowedClients(clientTypes ...string) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesis(func(g map[string]interface{}) error {
+		allowed := make([]interface{}, len(clientTypes))
+		for i, t := range clientTypes {
+			allowed[i] = t
+		}
+		return setGenesisValue(g, allowed, "app_state", "ibc", "client_genesis", "params", "allowed_clients")
+	})
+}
+
+// ModifyGenesisMaxExpectedTimePerBlock returns a ModifyGenesis function for ibc.ChainConfig
+// that sets the IBC connection parameter used to compute the block delay of packets.
+func ModifyGenesisMaxExpectedTimePerBlock(d time.Duration) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesis(func(g map[string]interface{}) error {
+		// The parameter is a uint64 of nanoseconds, which is encoded as a JSON string.
+		return setGenesisValue(g, strconv.FormatInt(d.Nanoseconds(), 10), "app_state", "ibc", "connection_genesis", "params", "max_expected_time_per_block")
+	})
+}
+
+// modifyGenesis returns a ModifyGenesis function that applies modify to the decoded genesis.
+func modifyGenesis(modify func(g map[string]interface{}) error) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g, err := unmarshalGenesis(genbz)
+		if err != nil {
+			return nil, err
+		}
+		if err := modify(g); err != nil {
+			return nil, err
+		}
+		return json.Marshal(g)
+	}
+}
+
+// genesisBondDenom returns the staking bond denom in genesis file content.
+func genesisBondDenom(genbz []byte) (string, error) {
+	g, err := unmarshalGenesis(genbz)
+	if err != nil {
+		return "", err
+	}
+	denom, ok := getGenesisValue(g, "app_state", "staking", "params", "bond_denom").(string)
+	if !ok || denom == "" {
+		return "", fmt.Errorf("genesis staking bond denom not found")
+	}
+	return denom, nil
+}
+
+// unmarshalGenesis decodes genesis file content into a generic map.
+// Numbers are preserved as json.Number so that large values survive re-encoding.
+func unmarshalGenesis(genbz []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(genbz))
+	dec.UseNumber()
+	var g map[string]interface{}
+	if err := dec.Decode(&g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+	return g, nil
+}
+
+// setGenesisValue sets the value at the given path of nested objects within g.
+// Every object along the path, except the last key, must already exist.
+func setGenesisValue(g map[string]interface{}, value interface{}, path ...string) error {
+	obj := g
+	for i, key := range path[:len(path)-1] {
+		next, ok := obj[key].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("genesis object %s not found", strings.Join(path[:i+1], "."))
+		}
+		obj = next
+	}
+	obj[path[len(path)-1]] = value
+	return nil
+}
+
+// getGenesisValue returns the value at the given path of nested objects within g,
+// or nil if there is no such value.
+func getGenesisValue(g map[string]interface{}, path ...string) interface{} {
+	var v interface{} = g
+	for _, key := range path {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = obj[key]
+	}
+	return v
+}
+
+// hasGenesisObject reports whether there is an object at the given path within g.
+func hasGenesisObject(g map[string]interface{}, path ...string) bool {
+	_, ok := getGenesisValue(g, path...).(map[string]interface{})
+	return ok
+}
@@ -0,0 +1,154 @@
+package cosmos_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModifyGenesisProposalTime(t *testing.T) {
+	const genesis = `{
+  "initial_height": "1",
+  "consensus_params": {"block": {"max_gas": 18446744073709551615}},
+  "app_state": {
+    "gov": {
+      "deposit_params": {"max_deposit_period": "172800s", "min_deposit": [{"denom": "stake", "amount": "10000000"}]},
+      "voting_params": {"voting_period": "172800s"}
+    }
+  }
+}`
+
+	out, err := cosmos.ModifyGenesisProposalTime("10s", "5s")(ibc.ChainConfig{}, []byte(genesis))
+	require.NoError(t, err)
+
+	var g struct {
+		ConsensusParams struct {
+			Block struct {
+				MaxGas json.Number `json:"max_gas"`
+			} `json:"block"`
+		} `json:"consensus_params"`
+		AppState struct {
+			Gov struct {
+				DepositParams struct {
+					MaxDepositPeriod string `json:"max_deposit_period"`
+				} `json:"deposit_params"`
+				VotingParams struct {
+					VotingPeriod string `json:"voting_period"`
+				} `json:"voting_params"`
+			} `json:"gov"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+
+	require.Equal(t, "10s", g.AppState.Gov.VotingParams.VotingPeriod)
+	require.Equal(t, "5s", g.AppState.Gov.DepositParams.MaxDepositPeriod)
+
+	// Unrelated large numbers must not lose precision.
+	require.Equal(t, json.Number("18446744073709551615"), g.ConsensusParams.Block.MaxGas)
+}
+
+func TestModifyGenesisProposalTime_MissingModule(t *testing.T) {
+	_, err := cosmos.ModifyGenesisProposalTime("10s", "5s")(ibc.ChainConfig{}, []byte(`{"app_state": {}}`))
+	require.EqualError(t, err, "genesis object app_state.gov not found")
+}
+
+func TestModifyGenesisSequence(t *testing.T) {
+	const genesis = `{
+  "app_state": {
+    "staking": {"params": {"bond_denom": "stake"}},
+    "mint": {"params": {"mint_denom": "stake"}},
+    "gov": {"deposit_params": {"min_deposit": [{"denom": "stake", "amount": "10000000"}]}},
+    "ibc": {
+      "client_genesis": {"params": {"allowed_clients": ["06-solomachine", "07-tendermint"]}},
+      "connection_genesis": {"params": {"max_expected_time_per_block": "30000000000"}}
+    }
+  }
+}`
+
+	modify := cosmos.ModifyGenesisSequence(
+		cosmos.ModifyGenesisStakingBondDenom("uatom"),
+		cosmos.ModifyGenesisAllowedClients("07-tendermint"),
+		cosmos.ModifyGenesisMaxExpectedTimePerBlock(10*time.Second),
+	)
+	out, err := modify(ibc.ChainConfig{}, []byte(genesis))
+	require.NoError(t, err)
+
+	var g struct {
+		AppState struct {
+			Staking struct {
+				Params struct {
+					BondDenom string `json:"bond_denom"`
+				} `json:"params"`
+			} `json:"staking"`
+			Mint struct {
+				Params struct {
+					MintDenom string `json:"mint_denom"`
+				} `json:"params"`
+			} `json:"mint"`
+			Gov struct {
+				DepositParams struct {
+					MinDeposit []struct {
+						Denom string `json:"denom"`
+					} `json:"min_deposit"`
+				} `json:"deposit_params"`
+			} `json:"gov"`
+			IBC struct {
+				ClientGenesis struct {
+					Params struct {
+						AllowedClients []string `json:"allowed_clients"`
+					} `json:"params"`
+				} `json:"client_genesis"`
+				ConnectionGenesis struct {
+					Params struct {
+						MaxExpectedTimePerBlock string `json:"max_expected_time_per_block"`
+					} `json:"params"`
+				} `json:"connection_genesis"`
+			} `json:"ibc"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+
+	require.Equal(t, "uatom", g.AppState.Staking.Params.BondDenom)
+	require.Equal(t, "uatom", g.AppState.Mint.Params.MintDenom)
+	require.Len(t, g.AppState.Gov.DepositParams.MinDeposit, 1)
+	require.Equal(t, "uatom", g.AppState.Gov.DepositParams.MinDeposit[0].Denom)
+	require.Equal(t, []string{"07-tendermint"}, g.AppState.IBC.ClientGenesis.Params.AllowedClients)
+	require.Equal(t, "10000000000", g.AppState.IBC.ConnectionGenesis.Params.MaxExpectedTimePerBlock)
+}
+
+func TestModifyGenesisSequence_Error(t *testing.T) {
+	modify := cosmos.ModifyGenesisSequence(
+		cosmos.ModifyGenesisAllowedClients("07-tendermint"),
+		func(ibc.ChainConfig, []byte) ([]byte, error) {
+			panic("should not be called after an error")
+		},
+	)
+	_, err := modify(ibc.ChainConfig{}, []byte(`{"app_state": {}}`))
+	require.EqualError(t, err, "genesis object app_state.ibc not found")
+}
+
+func TestModifyGenesisSignedBlocksWindow(t *testing.T) {
+	const genesis = `{"app_state": {"slashing": {"params": {"signed_blocks_window": "100", "min_signed_per_window": "0.500000000000000000"}}}}`
+
+	out, err := cosmos.ModifyGenesisSignedBlocksWindow(10, "0.1")(ibc.ChainConfig{}, []byte(genesis))
+	require.NoError(t, err)
+
+	var g struct {
+		AppState struct {
+			Slashing struct {
+				Params struct {
+					SignedBlocksWindow string `json:"signed_blocks_window"`
+					MinSignedPerWindow string `json:"min_signed_per_window"`
+				} `json:"params"`
+			} `json:"slashing"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+
+	require.Equal(t, "10", g.AppState.Slashing.Params.SignedBlocksWindow)
+	require.Equal(t, "0.1", g.AppState.Slashing.Params.MinSignedPerWindow)
+}
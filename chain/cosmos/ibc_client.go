@@ -0,0 +1,154 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	clienttypes "github.com/cosmos/ibc-go/v4/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v4/modules/core/exported"
+	ibctmtypes "github.com/cosmos/ibc-go/v4/modules/light-clients/07-tendermint/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// QueryClientState returns the state of the IBC client with the given ID on the chain.
+func (c *CosmosChain) QueryClientState(ctx context.Context, clientID string) (ibcexported.ClientState, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := clienttypes.NewQueryClient(conn)
+	res, err := queryClient.ClientState(ctx, &clienttypes.QueryClientStateRequest{ClientId: clientID})
+	if err != nil {
+		return nil, err
+	}
+
+	var clientState ibcexported.ClientState
+	if err := defaultEncoding.InterfaceRegistry.UnpackAny(res.ClientState, &clientState); err != nil {
+		return nil, fmt.Errorf("unpack client state of %s: %w", clientID, err)
+	}
+	return clientState, nil
+}
+
+// QueryClientStates returns the states of all IBC clients on the chain.
+func (c *CosmosChain) QueryClientStates(ctx context.Context) (clienttypes.IdentifiedClientStates, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := clienttypes.NewQueryClient(conn)
+	res, err := queryClient.ClientStates(ctx, &clienttypes.QueryClientStatesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cs := range res.ClientStates {
+		if err := cs.UnpackInterfaces(defaultEncoding.InterfaceRegistry); err != nil {
+			return nil, fmt.Errorf("unpack client state of %s: %w", cs.ClientId, err)
+		}
+	}
+	return res.ClientStates, nil
+}
+
+// QueryClientStatus returns the status of the IBC client with the given ID on the chain,
+// one of "Active", "Frozen", "Expired" or "Unknown".
+func (c *CosmosChain) QueryClientStatus(ctx context.Context, clientID string) (string, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	queryClient := clienttypes.NewQueryClient(conn)
+	res, err := queryClient.ClientStatus(ctx, &clienttypes.QueryClientStatusRequest{ClientId: clientID})
+	if err != nil {
+		return "", err
+	}
+	return res.Status, nil
+}
+
+// ValidatorSet returns the chain's validator set at height.
+func (c *CosmosChain) ValidatorSet(ctx context.Context, height int64) (*tmproto.ValidatorSet, error) {
+	// Request every validator in a single page; test chains have few validators.
+	page, perPage := 1, 100
+	res, err := c.getQueryNode().Client.Validators(ctx, &height, &page, &perPage)
+	if err != nil {
+		return nil, fmt.Errorf("tendermint rpc client validators at height %d: %w", height, err)
+	}
+	vals, err := tmtypes.NewValidatorSet(res.Validators).ToProto()
+	if err != nil {
+		return nil, fmt.Errorf("validator set at height %d: %w", height, err)
+	}
+	return vals, nil
+}
+
+// IBCHeader returns the signed header of the chain's block at height,
+// in the form a 07-tendermint client of the chain on a counterparty chain accepts as a client update.
+// trustedHeight is the height of the client's consensus state from which the header is verified,
+// which must be lower than height.
+func (c *CosmosChain) IBCHeader(ctx context.Context, height int64, trustedHeight clienttypes.Height) (*ibctmtypes.Header, error) {
+	res, err := c.getQueryNode().Client.Commit(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("tendermint rpc client commit at height %d: %w", height, err)
+	}
+
+	vals, err := c.ValidatorSet(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// The client's consensus state at the trusted height records the hash of the next validator set.
+	trustedVals, err := c.ValidatorSet(ctx, int64(trustedHeight.RevisionHeight)+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ibctmtypes.Header{
+		SignedHeader:      res.SignedHeader.ToProto(),
+		ValidatorSet:      vals,
+		TrustedHeight:     trustedHeight,
+		TrustedValidators: trustedVals,
+	}, nil
+}
+
+// UpdateClient updates the IBC client with the given ID on the chain with header, signed by keyName.
+func (c *CosmosChain) UpdateClient(ctx context.Context, keyName, clientID string, header ibcexported.Header) error {
+	txHash, err := c.getFullNode().UpdateClient(ctx, keyName, clientID, header)
+	if err != nil {
+		return err
+	}
+	txResp, err := c.getTransaction(txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	if txResp.Code != 0 {
+		return fmt.Errorf("client update transaction failed with code %d: %s", txResp.Code, txResp.RawLog)
+	}
+	return nil
+}
+
+// QueryChannel returns the end of the IBC channel with the given port and channel IDs on the chain.
+func (c *CosmosChain) QueryChannel(ctx context.Context, portID, channelID string) (*chantypes.Channel, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := chantypes.NewQueryClient(conn)
+	res, err := queryClient.Channel(ctx, &chantypes.QueryChannelRequest{PortId: portID, ChannelId: channelID})
+	if err != nil {
+		return nil, err
+	}
+	return res.Channel, nil
+}
@@ -0,0 +1,279 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/types"
+	feetypes "github.com/cosmos/ibc-go/v4/modules/apps/29-fee/types"
+	transfertypes "github.com/cosmos/ibc-go/v4/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v4/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// RegisterPayee registers payee as the address to receive the acknowledgement and timeout fees
+// of packets on the channel relayed by relayerAddr, signed by keyName, which must hold the relayer's key.
+func (c *CosmosChain) RegisterPayee(ctx context.Context, keyName, portID, channelID, relayerAddr, payee string) error {
+	_, err := c.getFullNode().feeTx(ctx, keyName, "register-payee", portID, channelID, relayerAddr, payee)
+	return err
+}
+
+// RegisterCounterpartyPayee registers counterpartyPayee, an address on the counterparty chain,
+// as the address to receive the receive fees of packets on the channel relayed by relayerAddr,
+// signed by keyName, which must hold the relayer's key.
+// It is submitted on the chain receiving the packets, i.e. the counterparty of the chain paying the fees.
+func (c *CosmosChain) RegisterCounterpartyPayee(ctx context.Context, keyName, portID, channelID, relayerAddr, counterpartyPayee string) error {
+	_, err := c.getFullNode().feeTx(ctx, keyName, "register-counterparty-payee", portID, channelID, relayerAddr, counterpartyPayee)
+	return err
+}
+
+// PayPacketFee escrows fee from keyName to incentivize relaying the packet with the given sequence,
+// which must already have been sent on the channel, returning the hash of the submitted transaction.
+// The CLI submits a MsgPayPacketFeeAsync; to pay for a transfer in the same transaction that sends it,
+// use SendIBCTransferWithFee.
+func (c *CosmosChain) PayPacketFee(ctx context.Context, keyName, portID, channelID string, sequence uint64, fee feetypes.Fee) (string, error) {
+	return c.getFullNode().feeTx(ctx, keyName, "pay-packet-fee", portID, channelID, strconv.FormatUint(sequence, 10),
+		"--recv-fee", fee.RecvFee.String(),
+		"--ack-fee", fee.AckFee.String(),
+		"--timeout-fee", fee.TimeoutFee.String(),
+	)
+}
+
+// SendIBCTransferWithFee sends amount from user over the transfer channel, and escrows fee from user for relaying
+// the sent packet, in a single transaction broadcast with b.
+// The transaction holds a MsgPayPacketFee, which pays for the next packet sent on the channel by its signer,
+// followed by the MsgTransfer sending that packet.
+//
+// As with SendIBCTransfer, timeouts are relative: a height timeout is added to the latest height of the channel's client,
+// and a timestamp timeout to the current time, with the defaults of the transfer command for any that is not set.
+func (c *CosmosChain) SendIBCTransferWithFee(ctx context.Context, b *Broadcaster, user User, channelID string, amount ibc.WalletAmount, fee feetypes.Fee, timeout *ibc.IBCTimeout) (tx ibc.Tx, _ error) {
+	const portID = "transfer"
+
+	timeoutHeight, timeoutTimestamp, err := c.transferTimeout(ctx, portID, channelID, timeout)
+	if err != nil {
+		return tx, err
+	}
+
+	sender := user.Bech32Address(c.Config().Bech32Prefix)
+	resp, err := BroadcastTx(ctx, b, user,
+		feetypes.NewMsgPayPacketFee(fee, portID, channelID, sender, nil),
+		transfertypes.NewMsgTransfer(
+			portID, channelID,
+			types.NewInt64Coin(amount.Denom, amount.Amount),
+			sender, amount.Address,
+			timeoutHeight, timeoutTimestamp,
+		),
+	)
+	if err != nil {
+		return tx, fmt.Errorf("broadcast incentivized ibc transfer: %w", err)
+	}
+	if resp.Code != 0 {
+		return tx, fmt.Errorf("incentivized ibc transfer transaction failed with code %d: %s", resp.Code, resp.RawLog)
+	}
+
+	txResp, err := c.getTransaction(resp.TxHash)
+	if err != nil {
+		return tx, fmt.Errorf("failed to get transaction %s: %w", resp.TxHash, err)
+	}
+	tx.Height = uint64(txResp.Height)
+	tx.TxHash = txResp.TxHash
+	// In cosmos, user is charged for entire gas requested, not the actual gas used.
+	tx.GasSpent = txResp.GasWanted
+
+	tx.Packet, err = sentPacket(txResp.Events)
+	if err != nil {
+		return tx, err
+	}
+	return tx, nil
+}
+
+// transferTimeout returns the absolute timeout height and timestamp of a transfer on the channel
+// for the relative timeout, applying the defaults of the transfer command as SendIBCTransfer does.
+func (c *CosmosChain) transferTimeout(ctx context.Context, portID, channelID string, timeout *ibc.IBCTimeout) (clienttypes.Height, uint64, error) {
+	relativeHeight, err := clienttypes.ParseHeight(transfertypes.DefaultRelativePacketTimeoutHeight)
+	if err != nil {
+		return clienttypes.Height{}, 0, fmt.Errorf("parse default timeout height: %w", err)
+	}
+	relativeTimestamp := transfertypes.DefaultRelativePacketTimeoutTimestamp
+	if timeout != nil {
+		if timeout.NanoSeconds > 0 {
+			relativeTimestamp = timeout.NanoSeconds
+		} else if timeout.Height > 0 {
+			relativeHeight = clienttypes.NewHeight(0, timeout.Height)
+		}
+	}
+
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return clienttypes.Height{}, 0, err
+	}
+	defer conn.Close()
+
+	queryClient := chantypes.NewQueryClient(conn)
+	res, err := queryClient.ChannelClientState(ctx, &chantypes.QueryChannelClientStateRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+	})
+	if err != nil {
+		return clienttypes.Height{}, 0, fmt.Errorf("query client state of channel %s: %w", channelID, err)
+	}
+	clientState, err := clienttypes.UnpackClientState(res.IdentifiedClientState.ClientState)
+	if err != nil {
+		return clienttypes.Height{}, 0, fmt.Errorf("unpack client state of channel %s: %w", channelID, err)
+	}
+
+	latest := clientState.GetLatestHeight()
+	timeoutHeight := clienttypes.NewHeight(
+		latest.GetRevisionNumber()+relativeHeight.GetRevisionNumber(),
+		latest.GetRevisionHeight()+relativeHeight.GetRevisionHeight(),
+	)
+	return timeoutHeight, uint64(time.Now().UnixNano()) + relativeTimestamp, nil
+}
+
+// feeTx runs the "tx ibc-fee" subcommand with the given arguments, signed by keyName,
+// returning the hash of the submitted transaction.
+func (tn *ChainNode) feeTx(ctx context.Context, keyName, subcommand string, args ...string) (string, error) {
+	command := append([]string{tn.Chain.Config().Bin, "tx", "ibc-fee", subcommand}, args...)
+	command = append(command,
+		"--keyring-backend", keyring.BackendTest,
+		"--gas-prices", tn.Chain.Config().GasPrices,
+		"--gas-adjustment", fmt.Sprint(tn.Chain.Config().GasAdjustment),
+		"--node", fmt.Sprintf("tcp://%s:26657", tn.HostName()),
+		"--from", keyName,
+		"--output", "json",
+		"-y",
+		"--home", tn.HomeDir(),
+		"--chain-id", tn.Chain.Config().ChainID,
+	)
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+	stdout, _, err := tn.Exec(ctx, command, nil)
+	if err != nil {
+		return "", err
+	}
+	var output CosmosTx
+	if err := json.Unmarshal(stdout, &output); err != nil {
+		return "", fmt.Errorf("parse %s output: %w", subcommand, err)
+	}
+	if output.Code != 0 {
+		return output.TxHash, fmt.Errorf("%s transaction failed with code %d: %s", subcommand, output.Code, output.RawLog)
+	}
+	if err := test.WaitForBlocks(ctx, 2, tn); err != nil {
+		return "", fmt.Errorf("wait for blocks: %w", err)
+	}
+	return output.TxHash, nil
+}
+
+// QueryFeeEnabledChannel reports whether the channel was opened with the fee middleware.
+func (c *CosmosChain) QueryFeeEnabledChannel(ctx context.Context, portID, channelID string) (bool, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	queryClient := feetypes.NewQueryClient(conn)
+	res, err := queryClient.FeeEnabledChannel(ctx, &feetypes.QueryFeeEnabledChannelRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.FeeEnabled, nil
+}
+
+// QueryIncentivizedPacket returns the fees escrowed for the packet with the given sequence,
+// which are paid out, and no longer found, once the packet is acknowledged or timed out.
+func (c *CosmosChain) QueryIncentivizedPacket(ctx context.Context, portID, channelID string, sequence uint64) (feetypes.IdentifiedPacketFees, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return feetypes.IdentifiedPacketFees{}, err
+	}
+	defer conn.Close()
+
+	queryClient := feetypes.NewQueryClient(conn)
+	res, err := queryClient.IncentivizedPacket(ctx, &feetypes.QueryIncentivizedPacketRequest{
+		PacketId: chantypes.NewPacketId(portID, channelID, sequence),
+	})
+	if err != nil {
+		return feetypes.IdentifiedPacketFees{}, err
+	}
+	return res.IncentivizedPacket, nil
+}
+
+// QueryIncentivizedPackets returns the fees escrowed for all the packets on the channel
+// that have not yet been acknowledged or timed out.
+func (c *CosmosChain) QueryIncentivizedPackets(ctx context.Context, portID, channelID string) ([]feetypes.IdentifiedPacketFees, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := feetypes.NewQueryClient(conn)
+	res, err := queryClient.IncentivizedPacketsForChannel(ctx, &feetypes.QueryIncentivizedPacketsForChannelRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	packets := make([]feetypes.IdentifiedPacketFees, len(res.IncentivizedPackets))
+	for i, p := range res.IncentivizedPackets {
+		packets[i] = *p
+	}
+	return packets, nil
+}
+
+// QueryPayee returns the payee registered for relayerAddr on the channel.
+func (c *CosmosChain) QueryPayee(ctx context.Context, channelID, relayerAddr string) (string, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	queryClient := feetypes.NewQueryClient(conn)
+	res, err := queryClient.Payee(ctx, &feetypes.QueryPayeeRequest{
+		ChannelId: channelID,
+		Relayer:   relayerAddr,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.PayeeAddress, nil
+}
+
+// QueryCounterpartyPayee returns the counterparty payee registered for relayerAddr on the channel.
+func (c *CosmosChain) QueryCounterpartyPayee(ctx context.Context, channelID, relayerAddr string) (string, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	queryClient := feetypes.NewQueryClient(conn)
+	res, err := queryClient.CounterpartyPayee(ctx, &feetypes.QueryCounterpartyPayeeRequest{
+		ChannelId: channelID,
+		Relayer:   relayerAddr,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.CounterpartyPayee, nil
+}
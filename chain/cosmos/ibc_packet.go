@@ -0,0 +1,130 @@
+package cosmos
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	chantypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// QueryPacketCommitments implements ibc.Chain, paging through the chain's packet commitments on the channel.
+func (c *CosmosChain) QueryPacketCommitments(ctx context.Context, portID, channelID string) ([]uint64, error) {
+	commitments, err := c.packetCommitments(ctx, portID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	seqs := make([]uint64, len(commitments))
+	for i, pc := range commitments {
+		seqs[i] = pc.Sequence
+	}
+	return seqs, nil
+}
+
+// QueryPacketCommitment implements ibc.Chain.
+func (c *CosmosChain) QueryPacketCommitment(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := chantypes.NewQueryClient(conn)
+	res, err := queryClient.PacketCommitment(ctx, &chantypes.QueryPacketCommitmentRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+		Sequence:  sequence,
+	})
+	if status.Code(err) == codes.NotFound {
+		// There is no commitment, such as once the acknowledgement of the packet is relayed.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.Commitment, nil
+}
+
+// QueryPacketReceipt implements ibc.Chain.
+func (c *CosmosChain) QueryPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	queryClient := chantypes.NewQueryClient(conn)
+	res, err := queryClient.PacketReceipt(ctx, &chantypes.QueryPacketReceiptRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+		Sequence:  sequence,
+	})
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return res.Received, nil
+}
+
+// QueryPacketAcknowledgement implements ibc.Chain.
+func (c *CosmosChain) QueryPacketAcknowledgement(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := chantypes.NewQueryClient(conn)
+	res, err := queryClient.PacketAcknowledgement(ctx, &chantypes.QueryPacketAcknowledgementRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+		Sequence:  sequence,
+	})
+	if status.Code(err) == codes.NotFound {
+		// The packet has not been acknowledged.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.Acknowledgement, nil
+}
+
+// packetCommitments returns all the packet commitments on the channel.
+func (c *CosmosChain) packetCommitments(ctx context.Context, portID, channelID string) ([]*chantypes.PacketState, error) {
+	grpcAddress := c.getQueryNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := chantypes.NewQueryClient(conn)
+	var (
+		commitments []*chantypes.PacketState
+		nextKey     []byte
+	)
+	for {
+		res, err := queryClient.PacketCommitments(ctx, &chantypes.QueryPacketCommitmentsRequest{
+			PortId:     portID,
+			ChannelId:  channelID,
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, err
+		}
+		commitments = append(commitments, res.Commitments...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return commitments, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
@@ -0,0 +1,93 @@
+package cosmos
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	chantypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// packetQueryServer serves the packet state of a channel with one packet, sequence 1,
+// answering like ibc-go does for any other sequence.
+type packetQueryServer struct {
+	*chantypes.UnimplementedQueryServer
+}
+
+func (packetQueryServer) PacketCommitment(_ context.Context, req *chantypes.QueryPacketCommitmentRequest) (*chantypes.QueryPacketCommitmentResponse, error) {
+	if req.Sequence != 1 {
+		return nil, status.Error(codes.NotFound, "packet commitment hash not found")
+	}
+	return &chantypes.QueryPacketCommitmentResponse{Commitment: []byte("commitment")}, nil
+}
+
+func (packetQueryServer) PacketReceipt(_ context.Context, req *chantypes.QueryPacketReceiptRequest) (*chantypes.QueryPacketReceiptResponse, error) {
+	return &chantypes.QueryPacketReceiptResponse{Received: req.Sequence == 1}, nil
+}
+
+func (packetQueryServer) PacketAcknowledgement(_ context.Context, req *chantypes.QueryPacketAcknowledgementRequest) (*chantypes.QueryPacketAcknowledgementResponse, error) {
+	if req.Sequence != 1 {
+		return nil, status.Error(codes.NotFound, "packet acknowledgement hash not found")
+	}
+	return &chantypes.QueryPacketAcknowledgementResponse{Acknowledgement: []byte("ack")}, nil
+}
+
+func (packetQueryServer) PacketCommitments(context.Context, *chantypes.QueryPacketCommitmentsRequest) (*chantypes.QueryPacketCommitmentsResponse, error) {
+	return nil, status.Error(codes.Unavailable, "node is catching up")
+}
+
+// newPacketQueryChain returns a chain whose only node serves the packet queries of packetQueryServer.
+func newPacketQueryChain(t *testing.T) *CosmosChain {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := grpc.NewServer()
+	chantypes.RegisterQueryServer(s, packetQueryServer{})
+	go func() {
+		_ = s.Serve(l)
+	}()
+	t.Cleanup(s.Stop)
+
+	return &CosmosChain{
+		numValidators: 1,
+		ChainNodes:    ChainNodes{{hostGRPCPort: l.Addr().String()}},
+	}
+}
+
+func TestCosmosChain_PacketQueries(t *testing.T) {
+	c := newPacketQueryChain(t)
+	ctx := context.Background()
+
+	commitment, err := c.QueryPacketCommitment(ctx, "transfer", "channel-0", 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("commitment"), commitment)
+
+	received, err := c.QueryPacketReceipt(ctx, "transfer", "channel-0", 1)
+	require.NoError(t, err)
+	require.True(t, received)
+
+	ack, err := c.QueryPacketAcknowledgement(ctx, "transfer", "channel-0", 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("ack"), ack)
+
+	// A packet without state is not an error.
+	commitment, err = c.QueryPacketCommitment(ctx, "transfer", "channel-0", 2)
+	require.NoError(t, err)
+	require.Nil(t, commitment)
+
+	received, err = c.QueryPacketReceipt(ctx, "transfer", "channel-0", 2)
+	require.NoError(t, err)
+	require.False(t, received)
+
+	ack, err = c.QueryPacketAcknowledgement(ctx, "transfer", "channel-0", 2)
+	require.NoError(t, err)
+	require.Nil(t, ack)
+
+	// Other errors are returned.
+	_, err = c.QueryPacketCommitments(ctx, "transfer", "channel-0")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,51 @@
+package cosmos
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pelletier/go-toml"
+)
+
+// applyTomlOverrides returns the TOML content with the settings in overrides applied.
+// Nested maps in overrides set keys within the corresponding TOML tables,
+// creating tables that do not already exist.
+func applyTomlOverrides(content []byte, overrides map[string]interface{}) ([]byte, error) {
+	tree, err := toml.LoadBytes(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse toml: %w", err)
+	}
+	setTomlOverrides(tree, nil, overrides)
+	return tree.Marshal()
+}
+
+func setTomlOverrides(tree *toml.Tree, path []string, overrides map[string]interface{}) {
+	for k, v := range overrides {
+		keyPath := append(append([]string(nil), path...), k)
+		if table, ok := v.(map[string]interface{}); ok {
+			setTomlOverrides(tree, keyPath, table)
+			continue
+		}
+		tree.SetPath(keyPath, tomlValue(v))
+	}
+}
+
+// tomlValue converts whole numbers decoded from JSON as float64 into integers,
+// so that integer settings are not written as floats.
+func tomlValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < math.MaxInt64 {
+			return int64(v)
+		}
+	case int:
+		return int64(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, elem := range v {
+			out[i] = tomlValue(elem)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,40 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyTomlOverrides(t *testing.T) {
+	const content = `
+minimum-gas-prices = ""
+pruning = "default"
+
+[api]
+enable = false
+address = "tcp://0.0.0.0:1317"
+`
+
+	out, err := applyTomlOverrides([]byte(content), map[string]interface{}{
+		"minimum-gas-prices": "0.01uatom",
+		"api": map[string]interface{}{
+			"enable": true,
+		},
+		"mempool": map[string]interface{}{
+			// Numbers decoded from JSON are float64.
+			"size": float64(5000),
+		},
+	})
+	require.NoError(t, err)
+
+	tree, err := toml.LoadBytes(out)
+	require.NoError(t, err)
+
+	require.Equal(t, "0.01uatom", tree.Get("minimum-gas-prices"))
+	require.Equal(t, "default", tree.Get("pruning"))
+	require.Equal(t, true, tree.GetPath([]string{"api", "enable"}))
+	require.Equal(t, "tcp://0.0.0.0:1317", tree.GetPath([]string{"api", "address"}))
+	require.Equal(t, int64(5000), tree.GetPath([]string{"mempool", "size"}))
+}
@@ -0,0 +1,75 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"go.uber.org/zap"
+)
+
+// TxResult is the result of delivering a transaction in a block.
+type TxResult struct {
+	// Hex-encoded hash of the transaction.
+	Hash string
+
+	// Bech32 addresses of the signers of the transaction's messages.
+	Signers []string
+
+	// Code is zero if the transaction succeeded.
+	Code uint32
+	Log  string
+
+	Events []abcitypes.Event
+}
+
+// TxResults returns the results of the transactions in the block at the given height,
+// including failed transactions, in block order.
+func (c *CosmosChain) TxResults(ctx context.Context, height uint64) ([]TxResult, error) {
+	h := int64(height)
+	client := c.getQueryNode().Client
+	blockRes, err := client.Block(ctx, &h)
+	if err != nil {
+		return nil, fmt.Errorf("block at height %d: %w", height, err)
+	}
+	resultsRes, err := client.BlockResults(ctx, &h)
+	if err != nil {
+		return nil, fmt.Errorf("block results at height %d: %w", height, err)
+	}
+	if len(resultsRes.TxsResults) != len(blockRes.Block.Txs) {
+		return nil, fmt.Errorf("block at height %d has %d txs but %d results", height, len(blockRes.Block.Txs), len(resultsRes.TxsResults))
+	}
+
+	results := make([]TxResult, len(blockRes.Block.Txs))
+	for i, tx := range blockRes.Block.Txs {
+		res := resultsRes.TxsResults[i]
+		results[i] = TxResult{
+			Hash:   fmt.Sprintf("%X", tx.Hash()),
+			Code:   res.Code,
+			Log:    res.Log,
+			Events: res.Events,
+		}
+
+		sdkTx, err := decodeTX(tx)
+		if err != nil {
+			// Keep the result, but without signers.
+			c.log.Info("Failed to decode tx", zap.Uint64("height", height), zap.Error(err))
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, msg := range sdkTx.GetMsgs() {
+			for _, signer := range msg.GetSigners() {
+				addr, err := types.Bech32ifyAddressBytes(c.Config().Bech32Prefix, signer)
+				if err != nil {
+					return nil, fmt.Errorf("bech32 signer of tx %s: %w", results[i].Hash, err)
+				}
+				if !seen[addr] {
+					seen[addr] = true
+					results[i].Signers = append(results[i].Signers, addr)
+				}
+			}
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,110 @@
+package penumbra
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Penumbra addresses are encoded with bech32m (BIP-350),
+// which the bech32 package used by the Cosmos SDK does not support.
+// Addresses are also longer than the 90 characters permitted by BIP-173,
+// so only the checksum algorithm is shared with other bech32m implementations.
+
+const (
+	bech32Charset        = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	bech32mConst  uint32 = 0x2bc830a3
+)
+
+// decodeBech32m decodes a bech32m string, returning its human-readable part
+// and its data converted to 8-bit bytes.
+func decodeBech32m(s string) (hrp string, data []byte, err error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, errors.New("bech32m string has mixed case")
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, fmt.Errorf("invalid bech32m separator position %d", sep)
+	}
+	hrp = s[:sep]
+	for _, c := range hrp {
+		if c < 33 || c > 126 {
+			return "", nil, fmt.Errorf("invalid character %q in bech32m human-readable part", c)
+		}
+	}
+
+	values := make([]byte, 0, len(s)-sep-1)
+	for _, c := range s[sep+1:] {
+		v := strings.IndexRune(bech32Charset, c)
+		if v < 0 {
+			return "", nil, fmt.Errorf("invalid character %q in bech32m data", c)
+		}
+		values = append(values, byte(v))
+	}
+
+	if bech32Polymod(append(bech32HRPExpand(hrp), values...)) != bech32mConst {
+		return "", nil, errors.New("invalid bech32m checksum")
+	}
+
+	// Drop the 6-character checksum before converting.
+	data, err = convertBits(values[:len(values)-6], 5, 8)
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, data, nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// convertBits regroups data from fromBits-bit groups to toBits-bit groups,
+// rejecting any incomplete trailing group that is not zero padding.
+func convertBits(data []byte, fromBits, toBits uint) ([]byte, error) {
+	var (
+		acc    uint32
+		bits   uint
+		out    []byte
+		maxVal = uint32(1)<<toBits - 1
+	)
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, fmt.Errorf("invalid data value %d", v)
+		}
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxVal))
+		}
+	}
+	if bits >= fromBits || acc<<(toBits-bits)&maxVal != 0 {
+		return nil, errors.New("invalid padding in bech32m data")
+	}
+	return out, nil
+}
@@ -0,0 +1,42 @@
+package penumbra
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeBech32m(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		// Test vector from BIP-350.
+		hrp, data, err := decodeBech32m("abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx")
+		require.NoError(t, err)
+		require.Equal(t, "abcdef", hrp)
+		require.Equal(t, "ffbbcdeb38bdab49ca307b9ac5a928398a418820", hex.EncodeToString(data))
+	})
+
+	t.Run("uppercase", func(t *testing.T) {
+		hrp, data, err := decodeBech32m("A1LQFN3A")
+		require.NoError(t, err)
+		require.Equal(t, "a", hrp)
+		require.Empty(t, data)
+	})
+
+	for _, tt := range []struct {
+		Name  string
+		Input string
+	}{
+		{"bech32 checksum", "a12uel5l"},
+		{"mixed case", "A1lqfn3a"},
+		{"bad checksum", "abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryy"},
+		{"no separator", "lqfn3a"},
+		{"invalid character", "a1lqfn3b"},
+	} {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			_, _, err := decodeBech32m(tt.Input)
+			require.Error(t, err)
+		})
+	}
+}
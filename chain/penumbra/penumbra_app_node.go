@@ -150,28 +150,58 @@ func (p *PenumbraAppNode) GenerateGenesisFile(
 	return err
 }
 
+// RecoverKey restores the wallet for mnemonic into a wallet file dedicated to keyName,
+// because a penumbra wallet holds a single seed phrase.
+// The wallet's address is labeled with keyName so it can be found by GetAddress.
+func (p *PenumbraAppNode) RecoverKey(ctx context.Context, keyName, mnemonic string) error {
+	walletPath := p.KeyWalletPathContainer(keyName)
+	cmd := []string{"pcli", "-w", walletPath, "wallet", "import-from-phrase", mnemonic}
+	if _, _, err := p.Exec(ctx, cmd, nil); err != nil {
+		return fmt.Errorf("importing wallet from phrase: %w", err)
+	}
+	cmd = []string{"pcli", "-w", walletPath, "addr", "new", keyName}
+	_, _, err := p.Exec(ctx, cmd, nil)
+	return err
+}
+
+// KeyWalletPathContainer is the path to the wallet created by RecoverKey for keyName.
+func (p *PenumbraAppNode) KeyWalletPathContainer(keyName string) string {
+	return filepath.Join(p.HomeDir(), "wallet-"+keyName)
+}
+
+// GetAddress returns the raw bytes of the address for keyName,
+// decoded from its bech32m representation.
 func (p *PenumbraAppNode) GetAddress(ctx context.Context, keyName string) ([]byte, error) {
-	cmd := []string{"pcli", "-w", p.WalletPathContainer(), "addr", "list"}
-	stdout, _, err := p.Exec(ctx, cmd, nil)
+	address, err := p.GetAddressBech32m(ctx, keyName)
 	if err != nil {
 		return nil, err
 	}
-	addresses := strings.Split(string(stdout), "\n")
-	for _, address := range addresses {
-		fields := strings.Fields(address)
-		if len(fields) < 3 {
-			continue
-		}
-		if fields[1] == keyName {
-			// TODO penumbra address is bech32m. need to decode to bytes here
-			return []byte(fields[2]), nil
-		}
+	_, bz, err := decodeBech32m(address)
+	if err != nil {
+		return nil, fmt.Errorf("decoding address %q: %w", address, err)
 	}
-	return []byte{}, errors.New("address not found")
+	return bz, nil
 }
 
+var errAddressNotFound = errors.New("address not found")
+
 func (p *PenumbraAppNode) GetAddressBech32m(ctx context.Context, keyName string) (string, error) {
-	cmd := []string{"pcli", "-w", p.WalletPathContainer(), "addr", "list"}
+	address, err := p.walletAddress(ctx, p.WalletPathContainer(), keyName)
+	if !errors.Is(err, errAddressNotFound) {
+		return address, err
+	}
+
+	// Keys restored through RecoverKey live in their own wallet.
+	address, err = p.walletAddress(ctx, p.KeyWalletPathContainer(keyName), keyName)
+	if err != nil {
+		return "", fmt.Errorf("%w for key %q", errAddressNotFound, keyName)
+	}
+	return address, nil
+}
+
+// walletAddress returns the bech32m address labeled keyName in the wallet at walletPath.
+func (p *PenumbraAppNode) walletAddress(ctx context.Context, walletPath, keyName string) (string, error) {
+	cmd := []string{"pcli", "-w", walletPath, "addr", "list"}
 	stdout, _, err := p.Exec(ctx, cmd, nil)
 	if err != nil {
 		return "", err
@@ -186,7 +216,7 @@ func (p *PenumbraAppNode) GetAddressBech32m(ctx context.Context, keyName string)
 			return fields[2], nil
 		}
 	}
-	return "", errors.New("address not found")
+	return "", errAddressNotFound
 }
 
 func (p *PenumbraAppNode) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
@@ -9,6 +9,8 @@ import (
 	"strconv"
 	"strings"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	chantypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
 	"github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
@@ -88,12 +90,61 @@ func NewPenumbraChain(log *zap.Logger, testName string, chainConfig ibc.ChainCon
 	}
 }
 
+// Acknowledgements implements ibc.Chain, returning all acknowledgments in block at height
 func (c *PenumbraChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
-	panic("implement me")
+	var acks []ibc.PacketAcknowledgement
+	err := rangeBlockIBCMessages(ctx, c.getRelayerNode().TendermintNode.Client, height, msgAcknowledgementTypeURL, func(value []byte) error {
+		var ack chantypes.MsgAcknowledgement
+		if err := ack.Unmarshal(value); err != nil {
+			return fmt.Errorf("unmarshal MsgAcknowledgement: %w", err)
+		}
+		acks = append(acks, ibc.PacketAcknowledgement{
+			Acknowledgement: ack.Acknowledgement,
+			Packet:          ibcPacket(ack.Packet),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+	}
+	return acks, nil
 }
 
+// Timeouts implements ibc.Chain, returning all timeouts in block at height
 func (c *PenumbraChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
-	panic("implement me")
+	var timeouts []ibc.PacketTimeout
+	err := rangeBlockIBCMessages(ctx, c.getRelayerNode().TendermintNode.Client, height, msgTimeoutTypeURL, func(value []byte) error {
+		var timeout chantypes.MsgTimeout
+		if err := timeout.Unmarshal(value); err != nil {
+			return fmt.Errorf("unmarshal MsgTimeout: %w", err)
+		}
+		timeouts = append(timeouts, ibc.PacketTimeout{
+			Packet: ibcPacket(timeout.Packet),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find timeouts at height %d: %w", height, err)
+	}
+	return timeouts, nil
+}
+
+const (
+	msgAcknowledgementTypeURL = "/ibc.core.channel.v1.MsgAcknowledgement"
+	msgTimeoutTypeURL         = "/ibc.core.channel.v1.MsgTimeout"
+)
+
+func ibcPacket(p chantypes.Packet) ibc.Packet {
+	return ibc.Packet{
+		Sequence:         p.Sequence,
+		SourcePort:       p.SourcePort,
+		SourceChannel:    p.SourceChannel,
+		DestPort:         p.DestinationPort,
+		DestChannel:      p.DestinationChannel,
+		Data:             p.Data,
+		TimeoutHeight:    p.TimeoutHeight.String(),
+		TimeoutTimestamp: ibc.Nanoseconds(p.TimeoutTimestamp),
+	}
 }
 
 // Implements Chain interface
@@ -142,8 +193,10 @@ func (c *PenumbraChain) GetHostGRPCAddress() string {
 	return c.getRelayerNode().PenumbraAppNode.hostGRPCPort
 }
 
+// HomeDir implements ibc.Chain.
+// Commands passed to Exec run against the penumbra app node, so this is that node's home directory.
 func (c *PenumbraChain) HomeDir() string {
-	panic(errors.New("HomeDir not implemented yet"))
+	return c.getRelayerNode().PenumbraAppNode.HomeDir()
 }
 
 // Implements Chain interface
@@ -151,8 +204,9 @@ func (c *PenumbraChain) CreateKey(ctx context.Context, keyName string) error {
 	return c.getRelayerNode().PenumbraAppNode.CreateKey(ctx, keyName)
 }
 
+// Implements Chain interface
 func (c *PenumbraChain) RecoverKey(ctx context.Context, name, mnemonic string) error {
-	return fmt.Errorf("RecoverKey not implemented for PenumbraChain")
+	return c.getRelayerNode().PenumbraAppNode.RecoverKey(ctx, name, mnemonic)
 }
 
 // Implements Chain interface
@@ -507,17 +561,58 @@ func (c *PenumbraChain) start(ctx context.Context) error {
 	return test.WaitForBlocks(ctx, 5, c.getRelayerNode().TendermintNode)
 }
 
+// errICANotSupported is returned by the interchain account methods,
+// as penumbra does not include the interchain accounts module.
+var errICANotSupported = errors.New("interchain accounts are not supported on penumbra")
+
+// RegisterInterchainAccount implements ibc.Chain.
+// It always returns an error, as penumbra does not support interchain accounts.
 func (c *PenumbraChain) RegisterInterchainAccount(ctx context.Context, keyName, connectionID string) (string, error) {
-	//TODO implement me
-	panic("implement me")
+	return "", errICANotSupported
 }
 
+// SendICABankTransfer implements ibc.Chain.
+// It always returns an error, as penumbra does not support interchain accounts.
 func (c *PenumbraChain) SendICABankTransfer(ctx context.Context, connectionID, fromAddr string, amount ibc.WalletAmount) error {
-	//TODO implement me
-	panic("implement me")
+	return errICANotSupported
 }
 
+// QueryInterchainAccount implements ibc.Chain.
+// It always returns an error, as penumbra does not support interchain accounts.
 func (c *PenumbraChain) QueryInterchainAccount(ctx context.Context, connectionID, address string) (string, error) {
-	//TODO implement me
-	panic("implement me")
+	return "", errICANotSupported
+}
+
+// SendICAMsg implements ibc.Chain.
+// It always returns an error, as penumbra does not support interchain accounts.
+func (c *PenumbraChain) SendICAMsg(ctx context.Context, connectionID, fromAddr string, msg sdk.Msg, maxBlocks uint64) (ibc.ICAResult, error) {
+	return ibc.ICAResult{}, errICANotSupported
+}
+
+// errPacketQueriesNotSupported is returned by the packet state queries,
+// as penumbra does not serve the IBC channel queries.
+var errPacketQueriesNotSupported = errors.New("packet state queries are not supported on penumbra")
+
+// QueryPacketCommitments implements ibc.Chain.
+// It always returns an error, as penumbra does not support packet state queries.
+func (c *PenumbraChain) QueryPacketCommitments(ctx context.Context, portID, channelID string) ([]uint64, error) {
+	return nil, errPacketQueriesNotSupported
+}
+
+// QueryPacketCommitment implements ibc.Chain.
+// It always returns an error, as penumbra does not support packet state queries.
+func (c *PenumbraChain) QueryPacketCommitment(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error) {
+	return nil, errPacketQueriesNotSupported
+}
+
+// QueryPacketReceipt implements ibc.Chain.
+// It always returns an error, as penumbra does not support packet state queries.
+func (c *PenumbraChain) QueryPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error) {
+	return false, errPacketQueriesNotSupported
+}
+
+// QueryPacketAcknowledgement implements ibc.Chain.
+// It always returns an error, as penumbra does not support packet state queries.
+func (c *PenumbraChain) QueryPacketAcknowledgement(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error) {
+	return nil, errPacketQueriesNotSupported
 }
@@ -0,0 +1,113 @@
+package penumbra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tmtypes "github.com/tendermint/tendermint/rpc/core/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type blockClient interface {
+	Block(ctx context.Context, height *int64) (*tmtypes.ResultBlock, error)
+}
+
+// maxMessageDepth bounds the recursion when searching a transaction for IBC messages.
+// Penumbra transactions nest IBC messages only a few levels deep (transaction, body, action, IBC action).
+const maxMessageDepth = 8
+
+// rangeBlockIBCMessages iterates through all a block's transactions,
+// yielding to f each IBC message whose type URL is typeURL, e.g. "/ibc.core.channel.v1.MsgAcknowledgement".
+//
+// Penumbra transactions are not Cosmos SDK transactions,
+// but each IBC action wraps the original ibc-go message in a google.protobuf.Any.
+// Rather than depending on the Penumbra protobuf definitions,
+// the transaction encoding is walked generically to find those Any values.
+func rangeBlockIBCMessages(ctx context.Context, client blockClient, height uint64, typeURL string, f func(value []byte) error) error {
+	h := int64(height)
+	block, err := client.Block(ctx, &h)
+	if err != nil {
+		return fmt.Errorf("tendermint rpc get block: %w", err)
+	}
+	for _, txbz := range block.Block.Txs {
+		for _, value := range findAnyValues(txbz, typeURL, 0) {
+			if err := f(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// findAnyValues returns the value of every google.protobuf.Any with the given typeURL
+// found in the protobuf-encoded message b, or in any message nested within b.
+func findAnyValues(b []byte, typeURL string, depth int) [][]byte {
+	if depth > maxMessageDepth {
+		return nil
+	}
+
+	if u, v, ok := parseAny(b); ok && u == typeURL {
+		return [][]byte{v}
+	}
+
+	var values [][]byte
+	for len(b) > 0 {
+		_, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			// Not a protobuf message; nothing more to find here.
+			return values
+		}
+		b = b[n:]
+
+		if typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return values
+			}
+			values = append(values, findAnyValues(v, typeURL, depth+1)...)
+			b = b[n:]
+			continue
+		}
+
+		n = protowire.ConsumeFieldValue(0, typ, b)
+		if n < 0 {
+			return values
+		}
+		b = b[n:]
+	}
+	return values
+}
+
+// parseAny attempts to decode b as a google.protobuf.Any,
+// returning its type URL and value.
+func parseAny(b []byte) (typeURL string, value []byte, ok bool) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 || typ != protowire.BytesType {
+			return "", nil, false
+		}
+		b = b[n:]
+
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return "", nil, false
+		}
+		b = b[n:]
+
+		switch num {
+		case 1:
+			typeURL = string(v)
+		case 2:
+			value = v
+		default:
+			return "", nil, false
+		}
+	}
+
+	if !strings.HasPrefix(typeURL, "/") || !utf8.ValidString(typeURL) {
+		return "", nil, false
+	}
+	return typeURL, value, true
+}
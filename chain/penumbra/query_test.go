@@ -0,0 +1,53 @@
+package penumbra
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	chantypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestFindAnyValues(t *testing.T) {
+	ack := chantypes.MsgAcknowledgement{
+		Packet: chantypes.Packet{
+			Sequence:      7,
+			SourcePort:    "transfer",
+			SourceChannel: "channel-0",
+			Data:          []byte("data"),
+		},
+		Acknowledgement: []byte(`{"result":"AQ=="}`),
+	}
+	ackBz, err := ack.Marshal()
+	require.NoError(t, err)
+
+	anyBz, err := (&codectypes.Any{TypeUrl: msgAcknowledgementTypeURL, Value: ackBz}).Marshal()
+	require.NoError(t, err)
+
+	// Mimic a transaction whose body holds actions, one of which wraps the IBC message.
+	var action []byte
+	action = protowire.AppendTag(action, 3, protowire.BytesType)
+	action = protowire.AppendBytes(action, anyBz)
+
+	var body []byte
+	body = protowire.AppendTag(body, 1, protowire.VarintType)
+	body = protowire.AppendVarint(body, 42)
+	body = protowire.AppendTag(body, 2, protowire.BytesType)
+	body = protowire.AppendBytes(body, []byte("not a message"))
+	body = protowire.AppendTag(body, 5, protowire.BytesType)
+	body = protowire.AppendBytes(body, action)
+
+	var tx []byte
+	tx = protowire.AppendTag(tx, 1, protowire.BytesType)
+	tx = protowire.AppendBytes(tx, body)
+
+	values := findAnyValues(tx, msgAcknowledgementTypeURL, 0)
+	require.Len(t, values, 1)
+
+	var got chantypes.MsgAcknowledgement
+	require.NoError(t, got.Unmarshal(values[0]))
+	require.Equal(t, ack, got)
+
+	require.Empty(t, findAnyValues(tx, msgTimeoutTypeURL, 0))
+}
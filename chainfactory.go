@@ -12,8 +12,8 @@ import (
 )
 
 // ChainFactory describes how to get chains for tests.
-// This type currently supports a Pair method,
-// but it may be expanded to a Triplet method in the future.
+// Factories of more than two chains, such as those returned by BuiltinChainFactory.WithTopology,
+// also implement TopologyChainFactory.
 type ChainFactory interface {
 	// Count reports how many chains this factory will produce from its Chains method.
 	Count() int
@@ -33,6 +33,19 @@ type ChainFactory interface {
 	Labels() []label.Chain
 }
 
+// TopologyChainFactory is a ChainFactory for a set of more than two chains,
+// linked as described by its Topology.
+type TopologyChainFactory interface {
+	ChainFactory
+
+	// Topology returns the shape of the links between the chains.
+	Topology() Topology
+
+	// Pair returns a factory of the two chains at indices i and j of the chain set,
+	// so that each link of the topology can be tested on its own.
+	Pair(i, j int) ChainFactory
+}
+
 // BuiltinChainFactory implements ChainFactory to return a fixed set of chains.
 // Use NewBuiltinChainFactory to create an instance.
 type BuiltinChainFactory struct {
@@ -57,6 +70,35 @@ func NewBuiltinChainFactory(log *zap.Logger, specs []*ChainSpec) *BuiltinChainFa
 	return &BuiltinChainFactory{log: log, specs: specs}
 }
 
+// WithTopology returns a TopologyChainFactory of the same chains as f,
+// whose links have the given topology.
+// f itself does not implement TopologyChainFactory.
+func (f *BuiltinChainFactory) WithTopology(t Topology) TopologyChainFactory {
+	return builtinTopologyChainFactory{BuiltinChainFactory: f, topology: t}
+}
+
+// builtinTopologyChainFactory is a BuiltinChainFactory whose chains are linked as described by topology.
+type builtinTopologyChainFactory struct {
+	*BuiltinChainFactory
+
+	topology Topology
+}
+
+// Topology returns the topology passed to WithTopology.
+func (f builtinTopologyChainFactory) Topology() Topology {
+	return f.topology
+}
+
+// Name prefixes the name of the chains with the topology.
+func (f builtinTopologyChainFactory) Name() string {
+	return string(f.topology) + ":" + f.BuiltinChainFactory.Name()
+}
+
+// Pair returns a factory of the chains at indices i and j.
+func (f builtinTopologyChainFactory) Pair(i, j int) ChainFactory {
+	return NewBuiltinChainFactory(f.log, []*ChainSpec{f.specs[i], f.specs[j]})
+}
+
 func (f *BuiltinChainFactory) Count() int {
 	return len(f.specs)
 }
@@ -30,6 +30,9 @@ type chainSet struct {
 	trackerEg  *errgroup.Group
 	db         *sql.DB
 	collectors []*blockdb.Collector
+
+	// Set during TrackBlocks, so that relayer logs can be saved alongside the blocks.
+	testCase *blockdb.TestCase
 }
 
 func newChainSet(log *zap.Logger, chains []ibc.Chain) *chainSet {
@@ -135,7 +138,7 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 // The gitSha is used to pin a git commit to a test invocation. Thus, when a user is looking at historical
 // data they are able to determine which version of the code produced the results.
 // Expected to be called after Start.
-func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string) error {
+func (cs *chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string) error {
 	if len(dbPath) == 0 {
 		// nop
 		return nil
@@ -160,6 +163,7 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 		_ = db.Close()
 		return fmt.Errorf("create test case in sqlite database: %w", err)
 	}
+	cs.testCase = testCase
 
 	// TODO (nix - 6/1/22) Need logger instead of fmt.Fprint
 	cs.trackerEg = new(errgroup.Group)
@@ -44,7 +44,23 @@ func init() {
 var testMatrix struct {
 	Relayers []string
 
+	// Pairs of chains.
 	ChainSets [][]*ibctest.ChainSpec
+
+	// Sets of more than two chains, linked in a topology.
+	ChainTopologies []chainTopology
+
+	// Paths of interchain topology files, relative to the matrix file;
+	// see ibctest.InterchainSpec for their format.
+	Interchains []string
+}
+
+// chainTopology is a set of more than two chains in the test matrix,
+// linked as described by the topology, e.g. "hub-and-spoke".
+type chainTopology struct {
+	Topology ibctest.Topology
+
+	Chains []*ibctest.ChainSpec
 }
 
 var debugFlagSet = flag.NewFlagSet("debug", flag.ExitOnError)
@@ -141,9 +157,30 @@ func validateTestMatrix() error {
 		}
 	}
 
+	for _, ct := range testMatrix.ChainTopologies {
+		if _, err := getTopologyChainFactory(nop, ct); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range testMatrix.Interchains {
+		if _, err := ibctest.ReadInterchainSpec(interchainSpecPath(p)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// interchainSpecPath resolves the path of an interchain topology file in the matrix
+// relative to the directory of the matrix file.
+func interchainSpecPath(p string) string {
+	if filepath.IsAbs(p) || extraFlags.MatrixFile == "" {
+		return p
+	}
+	return filepath.Join(filepath.Dir(extraFlags.MatrixFile), p)
+}
+
 var reporter *testreporter.Reporter
 
 func configureTestReporter() error {
@@ -186,6 +223,16 @@ func getChainFactory(log *zap.Logger, chainSpecs []*ibctest.ChainSpec) (ibctest.
 	return ibctest.NewBuiltinChainFactory(log, chainSpecs), nil
 }
 
+func getTopologyChainFactory(log *zap.Logger, ct chainTopology) (ibctest.ChainFactory, error) {
+	if len(ct.Chains) < 3 {
+		return nil, fmt.Errorf("chain topologies must have at least 3 chains (found a %s topology of %d chains)", ct.Topology, len(ct.Chains))
+	}
+	if err := ct.Topology.Validate(); err != nil {
+		return nil, err
+	}
+	return ibctest.NewBuiltinChainFactory(log, ct.Chains).WithTopology(ct.Topology), nil
+}
+
 // TestConformance is the root test for the ibc conformance tests.
 // It runs many subtests in parallel;
 // if this is too taxing on a system, the -test.parallel flag
@@ -202,8 +249,8 @@ func TestConformance(t *testing.T) {
 
 	log := logger.Logger
 
-	// Build a set of chain factories from the provided chain sets.
-	chainFactories := make([]ibctest.ChainFactory, 0, len(testMatrix.ChainSets))
+	// Build a set of chain factories from the provided chain sets and topologies.
+	chainFactories := make([]ibctest.ChainFactory, 0, len(testMatrix.ChainSets)+len(testMatrix.ChainTopologies))
 	for _, cs := range testMatrix.ChainSets {
 		cf, err := getChainFactory(log, cs)
 		if err != nil {
@@ -212,6 +259,14 @@ func TestConformance(t *testing.T) {
 		}
 		chainFactories = append(chainFactories, cf)
 	}
+	for _, ct := range testMatrix.ChainTopologies {
+		cf, err := getTopologyChainFactory(log, ct)
+		if err != nil {
+			// This error should have been validated before running tests.
+			panic(err)
+		}
+		chainFactories = append(chainFactories, cf)
+	}
 
 	// Materialize all the relayer factories.
 	relayerFactories := make([]ibctest.RelayerFactory, len(testMatrix.Relayers))
@@ -227,6 +282,24 @@ func TestConformance(t *testing.T) {
 
 	// Begin test execution, which will spawn many parallel subtests.
 	conformance.Test(t, chainFactories, relayerFactories, reporter)
+
+	if len(testMatrix.Interchains) > 0 {
+		t.Run("interchains", func(t *testing.T) {
+			for _, p := range testMatrix.Interchains {
+				spec, err := ibctest.ReadInterchainSpec(interchainSpecPath(p))
+				if err != nil {
+					// This error should have been validated before running tests.
+					panic(err)
+				}
+
+				t.Run(filepath.Base(p), func(t *testing.T) {
+					reporter.TrackParallel(t)
+
+					conformance.TestInterchainSpec(t, spec, log, reporter)
+				})
+			}
+		})
+	}
 }
 
 // addFlags configures additional flags beyond the default testing flags.
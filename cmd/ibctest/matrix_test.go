@@ -16,11 +16,22 @@ var (
 
 	//go:embed example_matrix_custom.json
 	exampleMatrixCustom string
+
+	//go:embed example_matrix_topology.json
+	exampleMatrixTopology string
+
+	//go:embed example_interchain.yaml
+	exampleInterchain string
 )
 
 func TestMatrixValid(t *testing.T) {
 	type matrix struct {
 		ChainSets [][]*ibctest.ChainSpec
+
+		ChainTopologies []struct {
+			Topology ibctest.Topology
+			Chains   []*ibctest.ChainSpec
+		}
 	}
 
 	for _, tc := range []struct {
@@ -29,6 +40,7 @@ func TestMatrixValid(t *testing.T) {
 	}{
 		{name: "example_matrix.json", j: exampleMatrix},
 		{name: "example_matrix_custom.json", j: exampleMatrixCustom},
+		{name: "example_matrix_topology.json", j: exampleMatrixTopology},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			var m matrix
@@ -40,6 +52,20 @@ func TestMatrixValid(t *testing.T) {
 					require.NoErrorf(t, err, "failed to generate config from chainset at index %d-%d", i, j)
 				}
 			}
+
+			for i, ct := range m.ChainTopologies {
+				_, err := ct.Topology.Edges(len(ct.Chains))
+				require.NoErrorf(t, err, "invalid topology at index %d", i)
+				for j, c := range ct.Chains {
+					_, err := c.Config()
+					require.NoErrorf(t, err, "failed to generate config from chain topology at index %d-%d", i, j)
+				}
+			}
 		})
 	}
 }
+
+func TestInterchainSpecValid(t *testing.T) {
+	_, err := ibctest.ParseInterchainSpec([]byte(exampleInterchain))
+	require.NoError(t, err)
+}
@@ -0,0 +1,212 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	ibcexported "github.com/cosmos/ibc-go/v4/modules/core/exported"
+	ibctmtypes "github.com/cosmos/ibc-go/v4/modules/light-clients/07-tendermint/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const (
+	// expiryTrustingPeriod is the trusting period of the client that is left to expire.
+	// It must be long enough for the relayer to create the link, during which the client is updated,
+	// and to update the client again once it has been substituted.
+	expiryTrustingPeriod = 90 * time.Second
+
+	// expiryVotingPeriod is the governance voting period on the chain hosting the client,
+	// so that the substitution proposal passes while the substitute client is still active.
+	expiryVotingPeriod = "10s"
+
+	// expiryProposalDeposit is the default gov module minimum deposit.
+	expiryProposalDeposit = 10_000_000
+)
+
+// TestRelayerClientExpiry lets the client on the first chain expire while the relayer is stopped,
+// checks that transfers over its channel fail,
+// then substitutes a new client for it through a governance proposal
+// and checks that the relayer can update the client and relay packets again.
+func TestRelayerClientExpiry(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	// The test needs a short trusting period for the second chain,
+	// and a short voting period for the first chain,
+	// so build single-validator chains from the factory's chain configurations.
+	for _, c := range chains {
+		if _, ok := c.(*cosmos.CosmosChain); !ok {
+			rep.TrackSkip(t, "skipping client expiry test for non-cosmos chain %T", c)
+		}
+	}
+	c0Cfg := chains[0].Config()
+	c0Cfg.ModifyGenesis = modifyGenesisAfter(c0Cfg.ModifyGenesis, cosmos.ModifyGenesisSequence(
+		cosmos.ModifyGenesisProposalTime(expiryVotingPeriod, expiryVotingPeriod),
+		// Deposit and vote in the denom the faucet and validators hold.
+		cosmos.ModifyGenesisStakingBondDenom(c0Cfg.Denom),
+	))
+	c1Cfg := chains[1].Config()
+	c1Cfg.TrustingPeriod = expiryTrustingPeriod.String()
+
+	log := zaptest.NewLogger(t)
+	c0 := cosmos.NewCosmosChain(t.Name(), c0Cfg, 1, 0, log)
+	c1 := cosmos.NewCosmosChain(t.Name(), c1Cfg, 1, 0, log)
+
+	r := rf.Build(t, client, network)
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: pathName,
+		})
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		HomeDir:           home,
+		Client:            client,
+		NetworkID:         network,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+	defer ic.Close()
+
+	// The client on c0 tracking c1, which is left to expire.
+	connections, err := r.GetConnections(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(connections, 1)
+	subjectClientID := connections[0].ClientID
+
+	clientState, err := c0.QueryClientState(ctx, subjectClientID)
+	req.NoError(err)
+	if tmClientState, ok := clientState.(*ibctmtypes.ClientState); !ok || tmClientState.TrustingPeriod != expiryTrustingPeriod {
+		rep.TrackSkip(t, "skipping because relayer did not create client with the configured trusting period %s", expiryTrustingPeriod)
+	}
+
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	c0ChannelID := channels[0].ChannelID
+
+	c1FaucetAddrBytes, err := c1.GetAddress(ctx, ibctest.FaucetAccountKeyName)
+	req.NoError(err)
+	c1FaucetAddr, err := types.Bech32ifyAddressBytes(c1.Config().Bech32Prefix, c1FaucetAddrBytes)
+	req.NoError(err)
+	transfer := ibc.WalletAmount{
+		Address: c1FaucetAddr,
+		Denom:   c0.Config().Denom,
+		Amount:  testCoinAmount,
+	}
+
+	t.Run("client expires", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		// With the relayer stopped, nothing updates the client.
+		maxBlocks := int(2 * expiryTrustingPeriod / (2 * time.Second))
+		status, err := waitForClientStatus(ctx, c0, subjectClientID, ibcexported.Expired, maxBlocks)
+		req.NoError(err)
+		req.Equal(ibcexported.Expired.String(), status, "client %s did not expire", subjectClientID)
+
+		_, err = c0.SendIBCTransfer(ctx, c0ChannelID, ibctest.FaucetAccountKeyName, transfer, nil)
+		req.Error(err, "transfer over the channel of an expired client should fail")
+	})
+
+	t.Run("client substitution", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		// Create a new client on c0 tracking c1, with the same parameters as the expired client.
+		const substitutePathName = "substitute"
+		req.NoError(r.GeneratePath(ctx, eRep, c0.Config().ChainID, c1.Config().ChainID, substitutePathName))
+		req.NoError(r.CreateClients(ctx, eRep, substitutePathName))
+
+		clientStates, err := c0.QueryClientStates(ctx)
+		req.NoError(err)
+		var substituteClientID string
+		for _, cs := range clientStates {
+			if cs.ClientId != subjectClientID {
+				substituteClientID = cs.ClientId
+			}
+		}
+		req.NotEmpty(substituteClientID, "substitute client not found")
+
+		proposalID, err := c0.ClientUpdateProposal(ctx, ibctest.FaucetAccountKeyName, cosmos.ClientUpdateProposal{
+			Deposit:            fmt.Sprintf("%d%s", expiryProposalDeposit, c0.Config().Denom),
+			Title:              "Substitute expired client",
+			Description:        fmt.Sprintf("Substitute client %s for expired client %s", substituteClientID, subjectClientID),
+			SubjectClientID:    subjectClientID,
+			SubstituteClientID: substituteClientID,
+		})
+		req.NoError(err)
+		req.NoError(c0.VoteOnProposalAllValidators(ctx, proposalID, cosmos.ProposalVoteYes))
+
+		proposal, err := c0.PollForProposalStatus(ctx, proposalID)
+		req.NoError(err)
+		req.Equal(govtypes.StatusPassed, proposal.Status, "client substitution proposal did not pass")
+
+		status, err := c0.QueryClientStatus(ctx, subjectClientID)
+		req.NoError(err)
+		req.Equal(ibcexported.Active.String(), status, "client %s not recovered by substitution", subjectClientID)
+	})
+
+	t.Run("relaying resumes", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		req.NoError(r.UpdateClients(ctx, eRep, pathName))
+
+		req.NoError(r.StartRelayer(ctx, eRep, pathName))
+		defer func() {
+			if err := r.StopRelayer(ctx, eRep); err != nil {
+				t.Logf("error stopping relayer: %v", err)
+			}
+		}()
+
+		beforeTransferHeight, err := c0.Height(ctx)
+		req.NoError(err)
+
+		tx, err := c0.SendIBCTransfer(ctx, c0ChannelID, ibctest.FaucetAccountKeyName, transfer, nil)
+		req.NoError(err)
+		req.NoError(tx.Validate())
+
+		_, err = test.PollForAck(ctx, c0, beforeTransferHeight, beforeTransferHeight+pollHeightMax, tx.Packet)
+		req.NoError(err, "packet not relayed after client substitution")
+	})
+}
+
+// modifyGenesisAfter returns a ModifyGenesis function applying modify after existing, if existing is set.
+func modifyGenesisAfter(existing, modify func(ibc.ChainConfig, []byte) ([]byte, error)) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	if existing == nil {
+		return modify
+	}
+	return cosmos.ModifyGenesisSequence(existing, modify)
+}
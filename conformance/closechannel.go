@@ -0,0 +1,46 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	chantypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRelayerCloseChannel opens its own channel, closes it with the relayer's CloseChannel,
+// and checks that both ends of the channel report CLOSED.
+//
+// Neither ICS-20 transfer nor interchain accounts, the applications of the supported chains,
+// allow a channel end to be closed with ChanCloseInit: both reject it in their OnChanCloseInit callback.
+// The only channel end those applications let close is that of an ordered channel on which a packet timed out,
+// so the test opens an interchain accounts channel, whose controller end it closes by timing out a packet,
+// and checks that CloseChannel completes the closing handshake on the host end.
+// The chains must therefore support interchain accounts, as in TestRelayerOrderedChannel.
+func TestRelayerCloseChannel(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+	requireCapabilities(t, rep, rf, relayer.CloseChannel)
+
+	ch := openICAChannel(t, cf, rf, rep)
+
+	ctx := context.Background()
+	req := require.New(rep.TestifyT(t))
+
+	ch.closeByTimeout(ctx, t, rep)
+
+	// Stop the relayer so that the host end is closed by CloseChannel rather than by the running relayer.
+	req.NoError(ch.stopRelayer(ctx))
+
+	req.NoError(ch.r.CloseChannel(ctx, ch.eRep, icaPathName, ch.channel.PortID, ch.channel.ChannelID))
+
+	chanEnd, err := ch.controllerEnd(ctx)
+	req.NoError(err)
+	req.Equal(chantypes.CLOSED, chanEnd.State, "controller channel end not closed")
+
+	hostEnd, err := ch.host.QueryChannel(ctx, chanEnd.Counterparty.PortId, chanEnd.Counterparty.ChannelId)
+	req.NoError(err)
+	req.Equal(chantypes.CLOSED, hostEnd.State, "host channel end not closed")
+}
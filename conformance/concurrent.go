@@ -0,0 +1,247 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrentPacketCount is the number of transfers sent while two relayers relay the same channel.
+const concurrentPacketCount = 5
+
+// redundantRelayErrors are substrings of the logs of relayer transactions
+// that are expected to fail when both relayers relay the same packet,
+// because the other relayer's transaction was included first.
+var redundantRelayErrors = []string{
+	"packet already received",
+	"packet messages are redundant",
+	"packet commitment not found",
+	"already exists",
+}
+
+// TestRelayerConcurrentRelayers relays transfers with two relayers running at once on the same path,
+// created by the relayer built by rf0 and shared by the relayer built by rf1,
+// which may be a different implementation.
+// It checks that every packet is received and acknowledged exactly once,
+// that neither relayer has a failed transaction other than an expected redundant relay,
+// and logs how many packets and acknowledgements each relayer won.
+func TestRelayerConcurrentRelayers(t *testing.T, cf ibctest.ChainFactory, rf0, rf1 ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	// The relayer of each packet is found through the signers of the chains' transactions.
+	c0, ok := chains[0].(*cosmos.CosmosChain)
+	if !ok {
+		rep.TrackSkip(t, "skipping concurrent relayers test for non-cosmos chain %T", chains[0])
+	}
+	c1, ok := chains[1].(*cosmos.CosmosChain)
+	if !ok {
+		rep.TrackSkip(t, "skipping concurrent relayers test for non-cosmos chain %T", chains[1])
+	}
+
+	r0 := rf0.Build(t, client, network)
+	r1 := rf1.Build(t, client, network)
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r0, "r0").
+		AddRelayer(r1, "r1").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r0,
+
+			Path: pathName,
+		}).
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r1,
+
+			Path:      pathName,
+			ShareWith: r0,
+		})
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		HomeDir:           home,
+		Client:            client,
+		NetworkID:         network,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+	defer ic.Close()
+
+	link := ic.Link(r0, pathName)
+	req.Len(link.Channels, 1)
+	channel := link.Channels[0]
+
+	// Both relayers must relay the single channel created by r0.
+	sharedLink := ic.Link(r1, pathName)
+	req.Equal(link.End1, sharedLink.End1)
+	req.Equal(link.Channels, sharedLink.Channels)
+
+	// Map the relayers' wallet addresses on each chain to the relayers' names.
+	relayerNames := map[string]string{
+		link.Wallet1.Address:       "r0",
+		link.Wallet2.Address:       "r0",
+		sharedLink.Wallet1.Address: "r1",
+		sharedLink.Wallet2.Address: "r1",
+	}
+
+	req.NoError(ic.StartRelayers(t, ctx, eRep))
+
+	c1FaucetAddrBytes, err := c1.GetAddress(ctx, ibctest.FaucetAccountKeyName)
+	req.NoError(err)
+	c1FaucetAddr, err := types.Bech32ifyAddressBytes(c1.Config().Bech32Prefix, c1FaucetAddrBytes)
+	req.NoError(err)
+
+	c0StartHeight, err := c0.Height(ctx)
+	req.NoError(err)
+	c1StartHeight, err := c1.Height(ctx)
+	req.NoError(err)
+
+	var sent []ibc.Packet
+	for i := 0; i < concurrentPacketCount; i++ {
+		tx, err := c0.SendIBCTransfer(ctx, channel.ChannelID, ibctest.FaucetAccountKeyName, ibc.WalletAmount{
+			Address: c1FaucetAddr,
+			Denom:   c0.Config().Denom,
+			Amount:  int64(1000 + i),
+		}, nil)
+		req.NoError(err)
+		req.NoError(tx.Validate())
+		sent = append(sent, tx.Packet)
+	}
+
+	for _, packet := range sent {
+		_, err := test.PollForAck(ctx, c0, c0StartHeight, c0StartHeight+pollHeightMax, packet)
+		req.NoError(err, "no acknowledgement for packet %d", packet.Sequence)
+	}
+
+	// Let both relayers finish any redundant transactions for the last packets.
+	req.NoError(test.WaitForBlocks(ctx, 5, c0, c1))
+
+	c0EndHeight, err := c0.Height(ctx)
+	req.NoError(err)
+	c1EndHeight, err := c1.Height(ctx)
+	req.NoError(err)
+
+	received, c1Failed, err := relayedPackets(ctx, c1, "recv_packet", channel.PortID, channel.ChannelID, c1StartHeight, c1EndHeight, relayerNames)
+	req.NoError(err)
+	acked, c0Failed, err := relayedPackets(ctx, c0, "acknowledge_packet", channel.PortID, channel.ChannelID, c0StartHeight, c0EndHeight, relayerNames)
+	req.NoError(err)
+
+	for _, packet := range sent {
+		req.Contains(received, packet.Sequence, "packet %d not received", packet.Sequence)
+		req.Contains(acked, packet.Sequence, "packet %d not acknowledged", packet.Sequence)
+	}
+	req.Len(received, len(sent))
+	req.Len(acked, len(sent))
+
+	recvWins := make(map[string]int)
+	for _, name := range received {
+		recvWins[name]++
+	}
+	ackWins := make(map[string]int)
+	for _, name := range acked {
+		ackWins[name]++
+	}
+	for _, name := range []string{"r0", "r1"} {
+		t.Logf("Relayer %s relayed %d of %d packets and %d of %d acknowledgements", name, recvWins[name], len(sent), ackWins[name], len(sent))
+	}
+
+	for _, res := range append(c0Failed, c1Failed...) {
+		req.True(isRedundantRelayError(res.Log), "relayer %s transaction %s failed with code %d: %s", relayerNames[res.Signers[0]], res.Hash, res.Code, res.Log)
+	}
+}
+
+// relayedPackets returns the name of the relayer that signed each event of eventType,
+// e.g. "recv_packet", for packets from the given source port and channel,
+// keyed by packet sequence, in the blocks of c from startHeight to endHeight inclusive.
+// It also returns the failed transactions signed by the relayers.
+// relayerNames maps the relayers' addresses to their names.
+func relayedPackets(
+	ctx context.Context,
+	c *cosmos.CosmosChain,
+	eventType, srcPort, srcChannel string,
+	startHeight, endHeight uint64,
+	relayerNames map[string]string,
+) (map[uint64]string, []cosmos.TxResult, error) {
+	relayed := make(map[uint64]string)
+	var failed []cosmos.TxResult
+	for h := startHeight; h <= endHeight; h++ {
+		results, err := c.TxResults(ctx, h)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tx results at height %d: %w", h, err)
+		}
+		for _, res := range results {
+			if len(res.Signers) == 0 {
+				continue
+			}
+			name, ok := relayerNames[res.Signers[0]]
+			if !ok {
+				continue
+			}
+			if res.Code != 0 {
+				failed = append(failed, res)
+				continue
+			}
+
+			for _, ev := range res.Events {
+				if ev.Type != eventType {
+					continue
+				}
+				attrs := make(map[string]string, len(ev.Attributes))
+				for _, a := range ev.Attributes {
+					attrs[string(a.Key)] = string(a.Value)
+				}
+				if attrs["packet_src_port"] != srcPort || attrs["packet_src_channel"] != srcChannel {
+					continue
+				}
+
+				seq, err := strconv.ParseUint(attrs["packet_sequence"], 10, 64)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid packet sequence %q: %w", attrs["packet_sequence"], err)
+				}
+				if prev, ok := relayed[seq]; ok {
+					return nil, nil, fmt.Errorf("packet %d relayed by both %s and %s", seq, prev, name)
+				}
+				relayed[seq] = name
+			}
+		}
+	}
+	return relayed, failed, nil
+}
+
+func isRedundantRelayError(log string) bool {
+	for _, s := range redundantRelayErrors {
+		if strings.Contains(log, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,273 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	feetypes "github.com/cosmos/ibc-go/v4/modules/apps/29-fee/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// Fees paid for each packet, distinct so that each payout is identifiable in the payees' balances.
+const (
+	recvFeeAmount    = 1001
+	ackFeeAmount     = 2002
+	timeoutFeeAmount = 3003
+)
+
+// relayerFeeKeyName is the name of the key, holding the relayer's wallet, in each chain's keyring.
+// Payees are registered with the relayer's own signature.
+const relayerFeeKeyName = "relayer-fee"
+
+// TestRelayerFeeMiddleware relays transfers on a channel opened with the ICS-29 fee middleware.
+// It registers payees for the relayer's wallets, and checks that the payees receive
+// the receive and acknowledgement fees of an acknowledged packet, whether the fee was paid
+// after the packet was sent or in the same transaction, and the timeout fee of a timed out packet.
+//
+// Both chains must include the fee middleware in their transfer stack.
+func TestRelayerFeeMiddleware(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+	requireCapabilities(t, rep, rf, relayer.FeeMiddleware)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	// Payees are registered, and fees are paid, with transactions of the fee module.
+	c0, ok := chains[0].(*cosmos.CosmosChain)
+	if !ok {
+		rep.TrackSkip(t, "skipping fee middleware test for non-cosmos chain %T", chains[0])
+	}
+	c1, ok := chains[1].(*cosmos.CosmosChain)
+	if !ok {
+		rep.TrackSkip(t, "skipping fee middleware test for non-cosmos chain %T", chains[1])
+	}
+
+	r := rf.Build(t, client, network)
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: pathName,
+		})
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	// The path is created below, once the chains are known to support the fee middleware.
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		HomeDir:   home,
+		Client:    client,
+		NetworkID: network,
+
+		SkipPathCreation: true,
+	}))
+	defer ic.Close()
+
+	opts := ibc.DefaultFeeChannelOpts()
+	for _, c := range []*cosmos.CosmosChain{c0, c1} {
+		if _, err := c.QueryIncentivizedPackets(ctx, opts.SourcePortName, "channel-0"); err != nil {
+			rep.TrackSkip(t, "skipping because chain %s does not support the fee middleware: %v", c.Config().ChainID, err)
+		}
+	}
+
+	req.NoError(r.GeneratePath(ctx, eRep, c0.Config().ChainID, c1.Config().ChainID, pathName))
+	req.NoError(r.LinkPath(ctx, eRep, pathName, opts))
+
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	channel := channels[0]
+
+	feeEnabled, err := c0.QueryFeeEnabledChannel(ctx, channel.PortID, channel.ChannelID)
+	req.NoError(err)
+	req.True(feeEnabled, "channel %s not fee enabled on chain %s", channel.ChannelID, c0.Config().ChainID)
+	feeEnabled, err = c1.QueryFeeEnabledChannel(ctx, channel.Counterparty.PortID, channel.Counterparty.ChannelID)
+	req.NoError(err)
+	req.True(feeEnabled, "channel %s not fee enabled on chain %s", channel.Counterparty.ChannelID, c1.Config().ChainID)
+
+	bech32Address := func(c *cosmos.CosmosChain, keyName string) string {
+		addrBytes, err := c.GetAddress(ctx, keyName)
+		req.NoError(err)
+		addr, err := types.Bech32ifyAddressBytes(c.Config().Bech32Prefix, addrBytes)
+		req.NoError(err)
+		return addr
+	}
+
+	// The receive fee is paid to the counterparty payee of the relayer of MsgRecvPacket on the second chain,
+	// and the acknowledgement and timeout fees to the payee of the relayer of MsgAcknowledgement or MsgTimeout.
+	// Both payees are new accounts on the first chain, whose balances are then exactly the fees paid.
+	for _, keyName := range []string{"recv-payee", "ack-payee"} {
+		req.NoError(c0.CreateKey(ctx, keyName))
+	}
+	recvPayee := bech32Address(c0, "recv-payee")
+	ackPayee := bech32Address(c0, "ack-payee")
+
+	w0, ok := r.GetWallet(c0.Config().ChainID)
+	req.True(ok, "relayer has no wallet on chain %s", c0.Config().ChainID)
+	w1, ok := r.GetWallet(c1.Config().ChainID)
+	req.True(ok, "relayer has no wallet on chain %s", c1.Config().ChainID)
+	req.NoError(c0.RecoverKey(ctx, relayerFeeKeyName, w0.Mnemonic))
+	req.NoError(c1.RecoverKey(ctx, relayerFeeKeyName, w1.Mnemonic))
+
+	req.NoError(c1.RegisterCounterpartyPayee(ctx, relayerFeeKeyName, channel.Counterparty.PortID, channel.Counterparty.ChannelID, w1.Address, recvPayee))
+	req.NoError(c0.RegisterPayee(ctx, relayerFeeKeyName, channel.PortID, channel.ChannelID, w0.Address, ackPayee))
+
+	counterpartyPayee, err := c1.QueryCounterpartyPayee(ctx, channel.Counterparty.ChannelID, w1.Address)
+	req.NoError(err)
+	req.Equal(recvPayee, counterpartyPayee)
+	payee, err := c0.QueryPayee(ctx, channel.ChannelID, w0.Address)
+	req.NoError(err)
+	req.Equal(ackPayee, payee)
+
+	denom := c0.Config().Denom
+	fee := feetypes.NewFee(
+		types.NewCoins(types.NewInt64Coin(denom, recvFeeAmount)),
+		types.NewCoins(types.NewInt64Coin(denom, ackFeeAmount)),
+		types.NewCoins(types.NewInt64Coin(denom, timeoutFeeAmount)),
+	)
+
+	c1FaucetAddr := bech32Address(c1, ibctest.FaucetAccountKeyName)
+
+	// sendIncentivized sends a transfer from the faucet, with the relayer stopped,
+	// and pays the fee for relaying it.
+	sendIncentivized := func(req *require.Assertions, timeout *ibc.IBCTimeout) (ibc.Tx, uint64) {
+		height, err := c0.Height(ctx)
+		req.NoError(err)
+
+		tx, err := c0.SendIBCTransfer(ctx, channel.ChannelID, ibctest.FaucetAccountKeyName, ibc.WalletAmount{
+			Address: c1FaucetAddr,
+			Denom:   denom,
+			Amount:  1,
+		}, timeout)
+		req.NoError(err)
+		req.NoError(tx.Validate())
+
+		_, err = c0.PayPacketFee(ctx, ibctest.FaucetAccountKeyName, channel.PortID, channel.ChannelID, tx.Packet.Sequence, fee)
+		req.NoError(err)
+
+		packetFees, err := c0.QueryIncentivizedPacket(ctx, channel.PortID, channel.ChannelID, tx.Packet.Sequence)
+		req.NoError(err)
+		req.Len(packetFees.PacketFees, 1)
+		req.Equal(fee, packetFees.PacketFees[0].Fee)
+
+		return tx, height
+	}
+
+	requireBalance := func(req *require.Assertions, addr string, want int64) {
+		balance, err := c0.GetBalance(ctx, addr, denom)
+		req.NoError(err)
+		req.Equal(want, balance, "unexpected balance of payee %s", addr)
+	}
+
+	t.Run("recv and ack fees", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		tx, height := sendIncentivized(req, nil)
+
+		req.NoError(r.StartRelayer(ctx, eRep, pathName))
+		_, err := test.PollForAck(ctx, c0, height, height+pollHeightMax, tx.Packet)
+		req.NoError(r.StopRelayer(ctx, eRep))
+		req.NoError(err, "no acknowledgement for packet %d", tx.Packet.Sequence)
+
+		requireBalance(req, recvPayee, recvFeeAmount)
+		requireBalance(req, ackPayee, ackFeeAmount)
+
+		// The fees are paid out, and the timeout fee refunded, once the packet is acknowledged.
+		packets, err := c0.QueryIncentivizedPackets(ctx, channel.PortID, channel.ChannelID)
+		req.NoError(err)
+		req.Empty(packets, "fees remain escrowed after acknowledgement")
+	})
+
+	// MsgPayPacketFee pays for the next packet sent on the channel, so it is broadcast with the transfer.
+	t.Run("fee paid with transfer", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		recvBalance, err := c0.GetBalance(ctx, recvPayee, denom)
+		req.NoError(err)
+		ackBalance, err := c0.GetBalance(ctx, ackPayee, denom)
+		req.NoError(err)
+
+		faucetAddr, err := c0.GetAddress(ctx, ibctest.FaucetAccountKeyName)
+		req.NoError(err)
+		faucet := &ibctest.User{Address: faucetAddr, KeyName: ibctest.FaucetAccountKeyName}
+
+		height, err := c0.Height(ctx)
+		req.NoError(err)
+
+		tx, err := c0.SendIBCTransferWithFee(ctx, cosmos.NewBroadcaster(t, c0), faucet, channel.ChannelID, ibc.WalletAmount{
+			Address: c1FaucetAddr,
+			Denom:   denom,
+			Amount:  1,
+		}, fee, nil)
+		req.NoError(err)
+		req.NoError(tx.Validate())
+
+		packetFees, err := c0.QueryIncentivizedPacket(ctx, channel.PortID, channel.ChannelID, tx.Packet.Sequence)
+		req.NoError(err)
+		req.Len(packetFees.PacketFees, 1)
+		req.Equal(fee, packetFees.PacketFees[0].Fee)
+
+		req.NoError(r.StartRelayer(ctx, eRep, pathName))
+		_, err = test.PollForAck(ctx, c0, height, height+pollHeightMax, tx.Packet)
+		req.NoError(r.StopRelayer(ctx, eRep))
+		req.NoError(err, "no acknowledgement for packet %d", tx.Packet.Sequence)
+
+		requireBalance(req, recvPayee, recvBalance+recvFeeAmount)
+		requireBalance(req, ackPayee, ackBalance+ackFeeAmount)
+	})
+
+	t.Run("timeout fee", func(t *testing.T) {
+		rep.TrackTest(t)
+		requireCapabilities(t, rep, rf, relayer.HeightTimeout)
+		req := require.New(rep.TestifyT(t))
+
+		recvBalance, err := c0.GetBalance(ctx, recvPayee, denom)
+		req.NoError(err)
+		ackBalance, err := c0.GetBalance(ctx, ackPayee, denom)
+		req.NoError(err)
+
+		tx, height := sendIncentivized(req, &ibc.IBCTimeout{Height: 10})
+
+		// Wait for the timeout height to pass on the second chain.
+		req.NoError(test.WaitForBlocks(ctx, 15, c0, c1))
+
+		req.NoError(r.StartRelayer(ctx, eRep, pathName))
+		_, err = test.PollForTimeout(ctx, c0, height, height+pollHeightMax, tx.Packet)
+		req.NoError(r.StopRelayer(ctx, eRep))
+		req.NoError(err, "packet %d not timed out", tx.Packet.Sequence)
+
+		// Only the timeout fee is paid; the receive and acknowledgement fees are refunded.
+		requireBalance(req, recvPayee, recvBalance)
+		requireBalance(req, ackPayee, ackBalance+timeoutFeeAmount)
+
+		packets, err := c0.QueryIncentivizedPackets(ctx, channel.PortID, channel.ChannelID)
+		req.NoError(err)
+		req.Empty(packets, "fees remain escrowed after timeout")
+	})
+}
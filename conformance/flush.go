@@ -7,6 +7,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
 	"github.com/strangelove-ventures/ibctest/ibc"
 	"github.com/strangelove-ventures/ibctest/relayer"
 	"github.com/strangelove-ventures/ibctest/test"
@@ -106,6 +107,26 @@ func TestRelayerFlushing(t *testing.T, cf ibctest.ChainFactory, rf ibctest.Relay
 		// Ack shouldn't happen yet.
 		_, err = test.PollForAck(ctx, c0, beforeTransferHeight, afterFlushHeight+2, tx.Packet)
 		req.ErrorIs(err, test.ErrNotFound)
+
+		t.Run("packet state", func(t *testing.T) {
+			rep.TrackTest(t)
+			requirePacketQueries(t, rep, c0, c1)
+
+			req := require.New(rep.TestifyT(t))
+
+			// The packet is received, but its commitment remains until the acknowledgement is relayed.
+			received, err := c1.QueryPacketReceipt(ctx, tx.Packet.DestPort, tx.Packet.DestChannel, tx.Packet.Sequence)
+			req.NoError(err)
+			req.True(received, "packet %d not received", tx.Packet.Sequence)
+
+			ack, err := c1.QueryPacketAcknowledgement(ctx, tx.Packet.DestPort, tx.Packet.DestChannel, tx.Packet.Sequence)
+			req.NoError(err)
+			req.NotEmpty(ack, "packet %d not acknowledged", tx.Packet.Sequence)
+
+			commitment, err := c0.QueryPacketCommitment(ctx, tx.Packet.SourcePort, tx.Packet.SourceChannel, tx.Packet.Sequence)
+			req.NoError(err)
+			req.NotEmpty(commitment, "packet %d commitment removed before acknowledgement was relayed", tx.Packet.Sequence)
+		})
 	})
 
 	t.Run("flush acks", func(t *testing.T) {
@@ -123,5 +144,29 @@ func TestRelayerFlushing(t *testing.T, cf ibctest.ChainFactory, rf ibctest.Relay
 		// Now the ack must be present.
 		_, err = test.PollForAck(ctx, c0, beforeTransferHeight, afterFlushHeight+2, tx.Packet)
 		req.NoError(err)
+
+		t.Run("packet state", func(t *testing.T) {
+			rep.TrackTest(t)
+			requirePacketQueries(t, rep, c0, c1)
+
+			req := require.New(rep.TestifyT(t))
+
+			// Nothing remains to be relayed on the channel.
+			commitments, err := c0.QueryPacketCommitments(ctx, tx.Packet.SourcePort, tx.Packet.SourceChannel)
+			req.NoError(err)
+			req.Empty(commitments, "unrelayed packet commitments remain after flushing")
+		})
 	})
 }
+
+// requirePacketQueries skips the test unless all the chains serve the IBC packet state queries,
+// which only Cosmos chains do.
+func requirePacketQueries(t *testing.T, rep *testreporter.Reporter, chains ...ibc.Chain) {
+	t.Helper()
+
+	for _, c := range chains {
+		if _, ok := c.(*cosmos.CosmosChain); !ok {
+			rep.TrackSkip(t, "skipping packet state checks for non-cosmos chain %T", c)
+		}
+	}
+}
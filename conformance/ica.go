@@ -0,0 +1,190 @@
+package conformance
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	chantypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// icaFundAmount is sent to the interchain account so that it can execute bank sends and delegations.
+	icaFundAmount = 1_000_000
+
+	// icaDelegateAmount is delegated by the interchain account to a validator of the host chain.
+	icaDelegateAmount = 1_000
+
+	// icaDepositAmount is deposited by the interchain account on a governance proposal of the host chain.
+	icaDepositAmount = 1_000
+)
+
+// TestRelayerInterchainAccounts relays the packets of an interchain account,
+// registered on the first chain and controlled from the faucet account.
+// It checks that messages of the bank, staking and gov modules are executed by the interchain account
+// and their results or errors acknowledged, and that, once its channel is closed by a timed out packet,
+// registering the account again reopens it on a new channel.
+//
+// The first chain must support the inter-tx interchain accounts controller commands,
+// and the second chain must host interchain accounts and allow the messages sent.
+func TestRelayerInterchainAccounts(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	ch := openICAChannel(t, cf, rf, rep)
+	r, c0, c1 := ch.r, ch.controller, ch.host
+	connectionID, ownerAddr, icaAddr, channel := ch.connectionID, ch.ownerAddr, ch.icaAddr, ch.channel
+
+	ctx := context.Background()
+	req := require.New(rep.TestifyT(t))
+	eRep := ch.eRep
+
+	bondDenom, err := c1.QueryBondDenom(ctx)
+	req.NoError(err)
+
+	// The faucet holds the chain's denom, and the bond denom if it differs.
+	fundDenoms := []string{c1.Config().Denom}
+	if bondDenom != c1.Config().Denom {
+		fundDenoms = append(fundDenoms, bondDenom)
+	}
+	for _, denom := range fundDenoms {
+		req.NoError(c1.SendFunds(ctx, ibctest.FaucetAccountKeyName, ibc.WalletAmount{
+			Address: icaAddr,
+			Denom:   denom,
+			Amount:  icaFundAmount,
+		}))
+	}
+
+	t.Run("bank messages executed", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		send := func(amount int64) types.Msg {
+			return &banktypes.MsgSend{
+				FromAddress: icaAddr,
+				ToAddress:   icaAddr,
+				Amount:      types.NewCoins(types.NewInt64Coin(c1.Config().Denom, amount)),
+			}
+		}
+
+		res, err := c0.SendICAMsg(ctx, connectionID, ownerAddr, send(1), pollHeightMax)
+		req.NoError(err)
+		req.False(res.TimedOut, "packet %d timed out", res.Packet.Sequence)
+		req.True(res.Success(), "message failed on host chain: %s", res.Error)
+		req.NotEmpty(res.Result)
+
+		// A failed message is acknowledged with an error, which does not close an ordered channel.
+		res, err = c0.SendICAMsg(ctx, connectionID, ownerAddr, send(2*icaFundAmount), pollHeightMax)
+		req.NoError(err)
+		req.False(res.TimedOut, "packet %d timed out", res.Packet.Sequence)
+		req.False(res.Success(), "send of more than the account's balance succeeded")
+		req.NotEmpty(res.Error)
+
+		chanEnd, err := ch.controllerEnd(ctx)
+		req.NoError(err)
+		req.Equal(chantypes.OPEN, chanEnd.State, "ordered channel not open after error acknowledgement")
+	})
+
+	t.Run("staking messages executed", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		valAddr, err := c1.Validators()[0].ValidatorOperatorAddress(ctx)
+		req.NoError(err)
+		before, err := c1.QueryValidator(ctx, valAddr)
+		req.NoError(err)
+
+		res, err := c0.SendICAMsg(ctx, connectionID, ownerAddr, &stakingtypes.MsgDelegate{
+			DelegatorAddress: icaAddr,
+			ValidatorAddress: valAddr,
+			Amount:           types.NewInt64Coin(bondDenom, icaDelegateAmount),
+		}, pollHeightMax)
+		req.NoError(err)
+		req.False(res.TimedOut, "packet %d timed out", res.Packet.Sequence)
+		req.True(res.Success(), "delegation failed on host chain: %s", res.Error)
+
+		after, err := c1.QueryValidator(ctx, valAddr)
+		req.NoError(err)
+		req.Equal(before.Tokens.AddRaw(icaDelegateAmount).String(), after.Tokens.String(), "delegation not bonded to validator %s", valAddr)
+	})
+
+	t.Run("gov messages executed", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		// A proposal below the minimum deposit stays in its deposit period, open to the interchain account's deposit.
+		proposalID, err := c1.TextProposal(ctx, ibctest.FaucetAccountKeyName, cosmos.TextProposal{
+			Deposit:     "1" + bondDenom,
+			Title:       "Interchain account deposit",
+			Description: "Proposal receiving a deposit from an interchain account",
+		})
+		req.NoError(err)
+		id, err := strconv.ParseUint(proposalID, 10, 64)
+		req.NoError(err)
+
+		res, err := c0.SendICAMsg(ctx, connectionID, ownerAddr, &govtypes.MsgDeposit{
+			ProposalId: id,
+			Depositor:  icaAddr,
+			Amount:     types.NewCoins(types.NewInt64Coin(bondDenom, icaDepositAmount)),
+		}, pollHeightMax)
+		req.NoError(err)
+		req.False(res.TimedOut, "packet %d timed out", res.Packet.Sequence)
+		req.True(res.Success(), "deposit failed on host chain: %s", res.Error)
+
+		prop, err := c1.QueryProposal(ctx, proposalID)
+		req.NoError(err)
+		req.Equal(int64(1+icaDepositAmount), prop.TotalDeposit.AmountOf(bondDenom).Int64(), "deposit not added to proposal %s", proposalID)
+	})
+
+	// The interchain account outlives its channel, and is controlled again through a new channel
+	// opened by registering the account again.
+	t.Run("reopen channel", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		ch.closeByTimeout(ctx, t, rep)
+
+		_, err := c0.RegisterInterchainAccount(ctx, ibctest.FaucetAccountKeyName, connectionID)
+		req.NoError(err)
+
+		var reopened string
+		for i := 0; i < icaHandshakeBlocks && reopened == ""; i++ {
+			req.NoError(test.WaitForBlocks(ctx, 1, c0))
+			channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+			req.NoError(err)
+			for _, c := range channels {
+				if c.PortID != channel.PortID || c.ChannelID == channel.ChannelID {
+					continue
+				}
+				chanEnd, err := c0.QueryChannel(ctx, c.PortID, c.ChannelID)
+				req.NoError(err)
+				if chanEnd.State == chantypes.OPEN {
+					reopened = c.ChannelID
+				}
+			}
+		}
+		req.NotEmpty(reopened, "interchain account channel not reopened within %d blocks", icaHandshakeBlocks)
+
+		reopenedAddr, err := c0.QueryInterchainAccount(ctx, connectionID, ownerAddr)
+		req.NoError(err)
+		req.Equal(icaAddr, reopenedAddr, "interchain account changed when its channel was reopened")
+
+		res, err := c0.SendICAMsg(ctx, connectionID, ownerAddr, &banktypes.MsgSend{
+			FromAddress: icaAddr,
+			ToAddress:   icaAddr,
+			Amount:      types.NewCoins(types.NewInt64Coin(c1.Config().Denom, 1)),
+		}, pollHeightMax)
+		req.NoError(err)
+		req.Equal(reopened, res.Packet.SourceChannel)
+		req.True(res.Success(), "message failed on host chain: %s", res.Error)
+	})
+}
@@ -0,0 +1,235 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	chantypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// icaHandshakeBlocks is the number of blocks within which the running relayer
+	// must complete the channel handshake started by interchain account registration.
+	icaHandshakeBlocks = 30
+
+	// maxForcedTimeout is the longest packet timeout the tests wait for to force a timeout.
+	maxForcedTimeout = 10 * time.Minute
+
+	icaControllerPortPrefix = "icacontroller-"
+
+	icaPathName = "p"
+)
+
+// icaChannel is an ordered interchain accounts channel between two cosmos chains,
+// opened by registering an interchain account on the controller chain, owned by its faucet account.
+type icaChannel struct {
+	r    ibc.Relayer
+	eRep *testreporter.RelayerExecReporter
+
+	controller, host *cosmos.CosmosChain
+
+	connectionID string
+	ownerAddr    string
+	icaAddr      string
+
+	// channel is the controller end of the channel.
+	channel ibc.ChannelOutput
+
+	relayerRunning bool
+}
+
+// openICAChannel builds an interchain of the two chains from cf, relayed by a relayer from rf,
+// registers an interchain account on the first chain and waits for the running relayer to open its channel.
+// The relayer is left running, and is stopped along with the interchain when t's cleanup runs.
+//
+// The test is skipped if the chains are not cosmos chains or if the first chain cannot register interchain accounts.
+func openICAChannel(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) *icaChannel {
+	t.Helper()
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	// Packets sent by the interchain accounts module are found through the chains' transaction events.
+	c0, ok := chains[0].(*cosmos.CosmosChain)
+	if !ok {
+		rep.TrackSkip(t, "skipping interchain accounts test for non-cosmos chain %T", chains[0])
+	}
+	c1, ok := chains[1].(*cosmos.CosmosChain)
+	if !ok {
+		rep.TrackSkip(t, "skipping interchain accounts test for non-cosmos chain %T", chains[1])
+	}
+
+	// The channel opened by registering the interchain account is only completed
+	// by relayers that complete channel handshakes started by applications.
+	if of, ok := rf.(ibctest.OptionsRelayerFactory); ok {
+		rf = of.WithOptions(relayer.CompleteChannelHandshakes())
+	}
+	r := rf.Build(t, client, network)
+
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: icaPathName,
+		})
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		HomeDir:           home,
+		Client:            client,
+		NetworkID:         network,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	ch := &icaChannel{
+		r:    r,
+		eRep: eRep,
+
+		controller: c0,
+		host:       c1,
+
+		connectionID: ic.Link(r, icaPathName).End1.ConnectionID,
+	}
+
+	ownerAddrBytes, err := c0.GetAddress(ctx, ibctest.FaucetAccountKeyName)
+	req.NoError(err)
+	ch.ownerAddr, err = types.Bech32ifyAddressBytes(c0.Config().Bech32Prefix, ownerAddrBytes)
+	req.NoError(err)
+
+	// The relayer must be running to complete the handshake of the channel opened by registration.
+	req.NoError(ch.startRelayer(ctx))
+	// Registered after the interchain's cleanup, so that it runs first.
+	t.Cleanup(func() {
+		if err := ch.stopRelayer(ctx); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	})
+
+	if _, err := c0.RegisterInterchainAccount(ctx, ibctest.FaucetAccountKeyName, ch.connectionID); err != nil {
+		rep.TrackSkip(t, "skipping because chain %s cannot register an interchain account: %v", c0.Config().ChainID, err)
+	}
+
+	for i := 0; i < icaHandshakeBlocks && ch.icaAddr == ""; i++ {
+		req.NoError(test.WaitForBlocks(ctx, 1, c0))
+		// The query fails until the channel is open.
+		ch.icaAddr, _ = c0.QueryInterchainAccount(ctx, ch.connectionID, ch.ownerAddr)
+	}
+	req.NotEmpty(ch.icaAddr, "interchain account channel not opened within %d blocks", icaHandshakeBlocks)
+
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	for _, c := range channels {
+		if strings.HasPrefix(c.PortID, icaControllerPortPrefix) {
+			ch.channel = c
+		}
+	}
+	req.NotEmpty(ch.channel.ChannelID, "interchain account controller channel not found")
+
+	return ch
+}
+
+// startRelayer starts the relayer on the channel's path, if it is not already running.
+func (ch *icaChannel) startRelayer(ctx context.Context) error {
+	if ch.relayerRunning {
+		return nil
+	}
+	if err := ch.r.StartRelayer(ctx, ch.eRep, icaPathName); err != nil {
+		return err
+	}
+	ch.relayerRunning = true
+	return nil
+}
+
+// stopRelayer stops the relayer, if it is running.
+func (ch *icaChannel) stopRelayer(ctx context.Context) error {
+	if !ch.relayerRunning {
+		return nil
+	}
+	if err := ch.r.StopRelayer(ctx, ch.eRep); err != nil {
+		return err
+	}
+	ch.relayerRunning = false
+	return nil
+}
+
+// controllerEnd returns the current state of the controller end of the channel.
+func (ch *icaChannel) controllerEnd(ctx context.Context) (*chantypes.Channel, error) {
+	return ch.controller.QueryChannel(ctx, ch.channel.PortID, ch.channel.ChannelID)
+}
+
+// closeByTimeout sends a packet over the channel while the relayer is stopped,
+// waits for it to time out, and restarts the relayer so that it relays the timeout,
+// which closes the controller end of the channel as ICS-4 requires of ordered channels.
+//
+// The test is skipped if the controller sets a packet timeout further than maxForcedTimeout in the future.
+func (ch *icaChannel) closeByTimeout(ctx context.Context, t *testing.T, rep *testreporter.Reporter) {
+	t.Helper()
+
+	req := require.New(rep.TestifyT(t))
+
+	req.NoError(ch.stopRelayer(ctx))
+
+	startHeight, err := ch.controller.Height(ctx)
+	req.NoError(err)
+	// The interchain account sends to itself; the content of the packet does not matter.
+	req.NoError(ch.controller.SendICABankTransfer(ctx, ch.connectionID, ch.ownerAddr, ibc.WalletAmount{
+		Address: ch.icaAddr,
+		Denom:   ch.host.Config().Denom,
+		Amount:  1,
+	}))
+	req.NoError(test.WaitForBlocks(ctx, 2, ch.controller))
+	endHeight, err := ch.controller.Height(ctx)
+	req.NoError(err)
+
+	sent, err := findPackets(ctx, ch.controller, "send_packet", ch.channel.PortID, ch.channel.ChannelID, startHeight, endHeight)
+	req.NoError(err)
+	req.Len(sent, 1)
+	packet := sent[0]
+
+	// The controller sets the packet timeout, so wait for it to pass with the relayer stopped.
+	timeout := time.Unix(0, int64(packet.TimeoutTimestamp))
+	if packet.TimeoutTimestamp == 0 || time.Until(timeout) > maxForcedTimeout {
+		rep.TrackSkip(t, "skipping because packet timeout %s is too far in the future", timeout)
+	}
+	time.Sleep(time.Until(timeout))
+	req.NoError(test.WaitForBlocks(ctx, 2, ch.host))
+
+	req.NoError(ch.startRelayer(ctx))
+
+	_, err = test.PollForTimeout(ctx, ch.controller, endHeight, endHeight+pollHeightMax, packet)
+	req.NoError(err, "packet %d not timed out", packet.Sequence)
+
+	chanEnd, err := ch.controllerEnd(ctx)
+	req.NoError(err)
+	req.Equal(chantypes.CLOSED, chanEnd.State, "ordered channel not closed by timeout")
+}
@@ -0,0 +1,58 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestInterchainSpec builds the Interchain described by spec, as loaded from a topology file.
+// It checks that every link of the spec has the channels declared for it,
+// and that every user of the spec is funded with the amount declared for it.
+func TestInterchainSpec(t *testing.T, spec *ibctest.InterchainSpec, log *zap.Logger, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	ctx := context.Background()
+	h, err := spec.Interchain(t, ctx, rep.RelayerExecReporter(t), log, ibctest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		HomeDir:   home,
+		Client:    client,
+		NetworkID: network,
+	})
+	req.NoError(err, "failed to build interchain")
+
+	for _, l := range spec.Links {
+		link := h.Interchain.Link(h.Relayers[l.Relayer], l.Path)
+		req.NotEmpty(link.End1.ConnectionID, "no connection for path %s of relayer %s", l.Path, l.Relayer)
+
+		if l.ShareWith != "" {
+			// The channels are those of the shared path, checked for its own link.
+			continue
+		}
+		if len(l.Channels) == 0 {
+			// The default transfer channel.
+			req.Len(link.Channels, 1, "unexpected channels for path %s of relayer %s", l.Path, l.Relayer)
+			continue
+		}
+		req.Len(link.Channels, len(l.Channels), "unexpected channels for path %s of relayer %s", l.Path, l.Relayer)
+		for i, ch := range link.Channels {
+			req.Equal(l.Channels[i].SourcePort, ch.PortID)
+			req.Equal(l.Channels[i].DestPort, ch.Counterparty.PortID)
+		}
+	}
+
+	for _, u := range spec.Users {
+		c := h.Chains[u.Chain]
+		balance, err := c.GetBalance(ctx, h.Users[u.Name].Bech32Address(c.Config().Bech32Prefix), c.Config().Denom)
+		req.NoError(err)
+		req.Equal(u.Amount, balance, "unexpected balance of user %s", u.Name)
+	}
+}
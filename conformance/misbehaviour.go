@@ -0,0 +1,137 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	clienttypes "github.com/cosmos/ibc-go/v4/modules/core/02-client/types"
+	ibcexported "github.com/cosmos/ibc-go/v4/modules/core/exported"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// misbehaviourDetectionBlocks is the number of blocks on the host chain
+// within which the relayer must freeze a client after a conflicting update.
+const misbehaviourDetectionBlocks = 30
+
+// TestRelayerMisbehaviour submits a client update with a header from a fork of the counterparty chain,
+// signed by the same validators, and checks that the running relayer detects the conflict
+// with the counterparty chain and submits misbehaviour evidence, freezing the client.
+func TestRelayerMisbehaviour(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+	requireCapabilities(t, rep, rf, relayer.Misbehaviour)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	// Forking the counterparty chain and submitting a client update require access to the chains' nodes.
+	c0, ok := chains[0].(*cosmos.CosmosChain)
+	if !ok {
+		rep.TrackSkip(t, "skipping misbehaviour test for non-cosmos chain %T", chains[0])
+	}
+	c1, ok := chains[1].(*cosmos.CosmosChain)
+	if !ok {
+		rep.TrackSkip(t, "skipping misbehaviour test for non-cosmos chain %T", chains[1])
+	}
+
+	r := rf.Build(t, client, network)
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: pathName,
+		})
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		HomeDir:           home,
+		Client:            client,
+		NetworkID:         network,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+	defer ic.Close()
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	// The client on c0 tracking c1.
+	connections, err := r.GetConnections(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(connections, 1)
+	clientID := connections[0].ClientID
+
+	fork, err := c1.StartFork(ctx)
+	req.NoError(err, "failed to start fork of counterparty chain")
+	defer func() {
+		if err := fork.StopAllNodes(ctx); err != nil {
+			t.Logf("error stopping fork: %v", err)
+		}
+	}()
+
+	clientState, err := c0.QueryClientState(ctx, clientID)
+	req.NoError(err)
+	trustedHeight := clientState.GetLatestHeight().(clienttypes.Height)
+
+	// Update the client past its latest height, so that the client accepts the update
+	// and only a comparison with the counterparty chain reveals the conflict.
+	// The fork starts from genesis, so it has to catch up with the client first.
+	conflictHeight := int64(trustedHeight.RevisionHeight) + 1
+	forkHeight, err := fork.Height(ctx)
+	req.NoError(err)
+	if delta := conflictHeight - int64(forkHeight) + 1; delta > 0 {
+		req.NoError(test.WaitForBlocks(ctx, int(delta), fork))
+	}
+
+	header, err := fork.IBCHeader(ctx, conflictHeight, trustedHeight)
+	req.NoError(err)
+	req.NoError(c0.UpdateClient(ctx, ibctest.FaucetAccountKeyName, clientID, header))
+
+	status, err := c0.QueryClientStatus(ctx, clientID)
+	req.NoError(err)
+	req.Equal(ibcexported.Active.String(), status, "client should accept the conflicting update")
+
+	status, err = waitForClientStatus(ctx, c0, clientID, ibcexported.Frozen, misbehaviourDetectionBlocks)
+	req.NoError(err)
+	req.Equal(ibcexported.Frozen.String(), status, "relayer did not freeze client %s within %d blocks", clientID, misbehaviourDetectionBlocks)
+}
+
+// waitForClientStatus waits up to maxBlocks blocks of c for the IBC client with the given ID to reach status,
+// returning the last status observed.
+func waitForClientStatus(ctx context.Context, c *cosmos.CosmosChain, clientID string, status ibcexported.Status, maxBlocks int) (string, error) {
+	got, err := c.QueryClientStatus(ctx, clientID)
+	for i := 0; err == nil && got != status.String() && i < maxBlocks; i++ {
+		if err := test.WaitForBlocks(ctx, 1, c); err != nil {
+			return got, err
+		}
+		got, err = c.QueryClientStatus(ctx, clientID)
+	}
+	return got, err
+}
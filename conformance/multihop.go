@@ -0,0 +1,179 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v4/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// routeHop is a transfer channel along the route of the multi-hop test.
+type routeHop struct {
+	Src, Dst ibc.Chain
+
+	// Channel on Src to Dst, and its counterparty on Dst.
+	SrcChannel, DstChannel string
+}
+
+func (h routeHop) reverse() routeHop {
+	return routeHop{Src: h.Dst, Dst: h.Src, SrcChannel: h.DstChannel, DstChannel: h.SrcChannel}
+}
+
+// TestRelayerMultiHop builds every chain of cf, linked as described by its topology,
+// with a relayer built by rf for each link.
+// It transfers the native token of the first chain of the longest route through the topology
+// to the last chain of the route, hop by hop, checking the denom trace of the voucher received on each chain,
+// and then sends the voucher back along the same route, checking that it is unwound to the native token.
+func TestRelayerMultiHop(t *testing.T, cf ibctest.TopologyChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	edges, err := cf.Topology().Edges(len(chains))
+	req.NoError(err)
+	route, err := cf.Topology().Route(len(chains))
+	req.NoError(err)
+
+	ic := ibctest.NewInterchain()
+	for _, c := range chains {
+		ic.AddChain(c)
+	}
+
+	// A relayer instance relays a single path, so each link has its own relayer.
+	relayers := make(map[[2]int]ibc.Relayer, len(edges))
+	for _, e := range edges {
+		r := rf.Build(t, client, network)
+		relayers[e] = r
+		ic.AddRelayer(r, fmt.Sprintf("r-%d-%d", e[0], e[1])).
+			AddLink(ibctest.InterchainLink{
+				Chain1:  chains[e[0]],
+				Chain2:  chains[e[1]],
+				Relayer: r,
+
+				Path: multiHopPathName(e),
+			})
+	}
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		HomeDir:           home,
+		Client:            client,
+		NetworkID:         network,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+	defer ic.Close()
+
+	// Channel on the lower index chain of each link.
+	channels := make(map[[2]int]ibc.ChannelOutput, len(edges))
+	for _, e := range edges {
+		r := relayers[e]
+		pathName := multiHopPathName(e)
+		channels[e] = ic.Link(r, pathName).Channels[0]
+	}
+
+	req.NoError(ic.StartRelayers(t, ctx, eRep))
+
+	hops := make([]routeHop, len(route)-1)
+	for i := range hops {
+		a, b := route[i], route[i+1]
+		hop := routeHop{Src: chains[a], Dst: chains[b]}
+		if a < b {
+			ch := channels[[2]int{a, b}]
+			hop.SrcChannel, hop.DstChannel = ch.ChannelID, ch.Counterparty.ChannelID
+		} else {
+			ch := channels[[2]int{b, a}]
+			hop.SrcChannel, hop.DstChannel = ch.Counterparty.ChannelID, ch.ChannelID
+		}
+		hops[i] = hop
+	}
+
+	routeChains := make([]ibc.Chain, len(route))
+	heighters := make([]test.ChainHeighter, len(route))
+	for i, idx := range route {
+		routeChains[i] = chains[idx]
+		heighters[i] = chains[idx]
+	}
+	users := ibctest.GetAndFundTestUsers(t, ctx, "multi-hop", userFaucetFund, routeChains...)
+	req.NoError(test.WaitForBlocks(ctx, 2, heighters...))
+
+	// transfer sends testCoinAmount of denom from the user on hop.Src to the user on hop.Dst,
+	// and waits for the transfer to be acknowledged.
+	transfer := func(req *require.Assertions, hop routeHop, from, to *ibctest.User, denom string) {
+		tx, err := hop.Src.SendIBCTransfer(ctx, hop.SrcChannel, from.KeyName, ibc.WalletAmount{
+			Address: to.Bech32Address(hop.Dst.Config().Bech32Prefix),
+			Denom:   denom,
+			Amount:  testCoinAmount,
+		}, nil)
+		req.NoError(err)
+		req.NoError(tx.Validate())
+
+		ack, err := test.PollForAck(ctx, hop.Src, tx.Height, tx.Height+pollHeightMax, tx.Packet)
+		req.NoError(err, "no acknowledgement of transfer from %s to %s", hop.Src.Config().ChainID, hop.Dst.Config().ChainID)
+		req.NoError(ack.Validate())
+	}
+
+	requireBalance := func(req *require.Assertions, c ibc.Chain, u *ibctest.User, denom string, want int64) {
+		balance, err := c.GetBalance(ctx, u.Bech32Address(c.Config().Bech32Prefix), denom)
+		req.NoError(err)
+		req.Equal(want, balance, "unexpected balance of %s on %s", denom, c.Config().ChainID)
+	}
+
+	// Full denom, with the trace prefix, of the token sent from the first chain, on each chain of the route.
+	nativeDenom := routeChains[0].Config().Denom
+	denoms := []string{nativeDenom}
+
+	t.Run("forward", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		for i, hop := range hops {
+			denom := denoms[i]
+			transfer(req, hop, users[i], users[i+1], transfertypes.ParseDenomTrace(denom).IBCDenom())
+
+			next := transfertypes.GetPrefixedDenom("transfer", hop.DstChannel, denom)
+			denoms = append(denoms, next)
+			requireBalance(req, hop.Dst, users[i+1], transfertypes.ParseDenomTrace(next).IBCDenom(), testCoinAmount)
+		}
+	})
+
+	t.Run("unwind", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		if len(denoms) != len(route) {
+			rep.TrackSkip(t, "skipping because the token was not forwarded along the route")
+		}
+
+		nativeBalance, err := routeChains[0].GetBalance(ctx, users[0].Bech32Address(routeChains[0].Config().Bech32Prefix), nativeDenom)
+		req.NoError(err)
+
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := hops[i].reverse()
+			voucher := transfertypes.ParseDenomTrace(denoms[i+1]).IBCDenom()
+			transfer(req, hop, users[i+1], users[i], voucher)
+
+			requireBalance(req, hop.Src, users[i+1], voucher, 0)
+		}
+
+		// Every voucher was burned on its way back, so the sender holds the native token again.
+		requireBalance(req, routeChains[0], users[0], nativeDenom, nativeBalance+testCoinAmount)
+	})
+}
+
+func multiHopPathName(e [2]int) string {
+	return fmt.Sprintf("p-%d-%d", e[0], e[1])
+}
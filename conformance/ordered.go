@@ -0,0 +1,142 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	chantypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// orderedPacketCount is the number of packets sent at once over the ordered channel.
+const orderedPacketCount = 3
+
+// TestRelayerOrderedChannel exercises an ordered interchain accounts channel,
+// opened by registering an interchain account on the first chain, controlled from the faucet account.
+// It checks that packets sent while the relayer is stopped are received in sequence order,
+// and that a timed out packet closes the channel, as ICS-4 requires of ordered channels.
+//
+// The first chain must support the inter-tx interchain accounts controller commands,
+// and the second chain must host interchain accounts.
+func TestRelayerOrderedChannel(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	ch := openICAChannel(t, cf, rf, rep)
+	c0, c1 := ch.controller, ch.host
+	connectionID, ownerAddr, icaAddr, channel := ch.connectionID, ch.ownerAddr, ch.icaAddr, ch.channel
+
+	ctx := context.Background()
+	req := require.New(rep.TestifyT(t))
+
+	chanEnd, err := ch.controllerEnd(ctx)
+	req.NoError(err)
+	req.Equal(chantypes.ORDERED, chanEnd.Ordering)
+
+	// The interchain account sends to itself; the content of the packets does not matter to the test.
+	icaSend := ibc.WalletAmount{
+		Address: icaAddr,
+		Denom:   c1.Config().Denom,
+		Amount:  1,
+	}
+
+	t.Run("packets relayed in order", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		// Queue packets while the relayer is stopped, so that it relays them together.
+		req.NoError(ch.stopRelayer(ctx))
+
+		startHeight, err := c0.Height(ctx)
+		req.NoError(err)
+		for i := 0; i < orderedPacketCount; i++ {
+			req.NoError(c0.SendICABankTransfer(ctx, connectionID, ownerAddr, icaSend))
+			// Wait for inclusion before sending from the same account again.
+			req.NoError(test.WaitForBlocks(ctx, 2, c0))
+		}
+		endHeight, err := c0.Height(ctx)
+		req.NoError(err)
+
+		sent, err := findPackets(ctx, c0, "send_packet", channel.PortID, channel.ChannelID, startHeight, endHeight)
+		req.NoError(err)
+		req.Len(sent, orderedPacketCount)
+
+		c1StartHeight, err := c1.Height(ctx)
+		req.NoError(err)
+
+		req.NoError(ch.startRelayer(ctx))
+
+		for _, packet := range sent {
+			_, err := test.PollForAck(ctx, c0, endHeight, endHeight+pollHeightMax, packet)
+			req.NoError(err, "no acknowledgement for packet %d", packet.Sequence)
+		}
+
+		c1EndHeight, err := c1.Height(ctx)
+		req.NoError(err)
+		received, err := findPackets(ctx, c1, "recv_packet", channel.PortID, channel.ChannelID, c1StartHeight, c1EndHeight)
+		req.NoError(err)
+		req.Len(received, len(sent))
+		for i := range sent {
+			req.Equal(sent[i].Sequence, received[i].Sequence, "packets received out of order")
+		}
+	})
+
+	t.Run("timeout closes channel", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		ch.closeByTimeout(ctx, t, rep)
+	})
+}
+
+// findPackets returns the packets from the given source port and channel
+// in events of eventType, e.g. "send_packet" or "recv_packet",
+// in the blocks of c from startHeight to endHeight inclusive, in the order of the events.
+func findPackets(ctx context.Context, c *cosmos.CosmosChain, eventType, srcPort, srcChannel string, startHeight, endHeight uint64) ([]ibc.Packet, error) {
+	var packets []ibc.Packet
+	for h := startHeight; h <= endHeight; h++ {
+		txs, err := c.FindTxs(ctx, h)
+		if err != nil {
+			return nil, fmt.Errorf("find txs at height %d: %w", h, err)
+		}
+		for _, tx := range txs {
+			for _, ev := range tx.Events {
+				if ev.Type != eventType {
+					continue
+				}
+				attrs := make(map[string]string, len(ev.Attributes))
+				for _, a := range ev.Attributes {
+					attrs[a.Key] = a.Value
+				}
+				if attrs["packet_src_port"] != srcPort || attrs["packet_src_channel"] != srcChannel {
+					continue
+				}
+
+				seq, err := strconv.ParseUint(attrs["packet_sequence"], 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid packet sequence %q: %w", attrs["packet_sequence"], err)
+				}
+				timeoutTs, err := strconv.ParseUint(attrs["packet_timeout_timestamp"], 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid packet timeout timestamp %q: %w", attrs["packet_timeout_timestamp"], err)
+				}
+				packets = append(packets, ibc.Packet{
+					Sequence:         seq,
+					SourcePort:       srcPort,
+					SourceChannel:    srcChannel,
+					DestPort:         attrs["packet_dst_port"],
+					DestChannel:      attrs["packet_dst_channel"],
+					Data:             []byte(attrs["packet_data"]),
+					TimeoutHeight:    attrs["packet_timeout_height"],
+					TimeoutTimestamp: ibc.Nanoseconds(timeoutTs),
+				})
+			}
+		}
+	}
+	return packets, nil
+}
@@ -190,15 +190,26 @@ func sendIBCTransfersFromBothChainsWithTimeout(
 //
 // This function accepts the full set of chain factories and relayer factories to use,
 // so that it can properly group subtests in a single invocation.
+// Chain factories of more than two chains must implement ibctest.TopologyChainFactory.
 // If the subtest configuration does not meet your needs,
 // you can directly call one of the other exported Test functions, such as TestChainPair.
 func Test(t *testing.T, cfs []ibctest.ChainFactory, rfs []ibctest.RelayerFactory, rep *testreporter.Reporter) {
 	// Validate chain factory counts up front.
 	counts := make(map[int]bool)
+	var topologyFactories []ibctest.TopologyChainFactory
 	for _, cf := range cfs {
-		switch count := cf.Count(); count {
-		case 2:
+		switch count := cf.Count(); {
+		case count == 2:
 			counts[count] = true
+		case count > 2:
+			tcf, ok := cf.(ibctest.TopologyChainFactory)
+			if !ok {
+				panic(fmt.Errorf("cannot accept chain factory with count=%d and no topology", count))
+			}
+			if _, err := tcf.Topology().Edges(count); err != nil {
+				panic(fmt.Errorf("cannot accept chain factory %s: %w", cf.Name(), err))
+			}
+			topologyFactories = append(topologyFactories, tcf)
 		default:
 			panic(fmt.Errorf("cannot accept chain factory with count=%d", cf.Count()))
 		}
@@ -214,9 +225,13 @@ func Test(t *testing.T, cfs []ibctest.ChainFactory, rfs []ibctest.RelayerFactory
 				}
 
 				t.Run(cf.Name(), func(t *testing.T) {
-					for _, rf := range rfs {
+					for i, rf := range rfs {
 						rf := rf
 
+						// The relayer sharing a path with rf in the concurrent relayers test,
+						// of a different implementation if there is more than one.
+						peer := rfs[(i+1)%len(rfs)]
+
 						t.Run(rf.Name(), func(t *testing.T) {
 							// Record the labels for this nested test.
 							rep.TrackParameters(t, rf.Labels(), cf.Labels())
@@ -242,6 +257,97 @@ func Test(t *testing.T, cfs []ibctest.ChainFactory, rfs []ibctest.RelayerFactory
 
 								TestRelayerFlushing(t, cf, rf, rep)
 							})
+
+							t.Run("misbehaviour", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerMisbehaviour(t, cf, rf, rep)
+							})
+
+							t.Run("client expiry", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerClientExpiry(t, cf, rf, rep)
+							})
+
+							t.Run("ordered channel", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerOrderedChannel(t, cf, rf, rep)
+							})
+
+							t.Run("interchain accounts", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerInterchainAccounts(t, cf, rf, rep)
+							})
+
+							t.Run("close channel", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerCloseChannel(t, cf, rf, rep)
+							})
+
+							t.Run("concurrent relayers", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerConcurrentRelayers(t, cf, rf, peer, rep)
+							})
+
+							t.Run("fee middleware", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerFeeMiddleware(t, cf, rf, rep)
+							})
+						})
+					}
+				})
+			}
+		})
+	}
+
+	// Sets of more than two chains run the pair tests on each link of their topology,
+	// and the multi-hop test across the links.
+	if len(topologyFactories) > 0 {
+		t.Run("chain topologies", func(t *testing.T) {
+			for _, cf := range topologyFactories {
+				cf := cf
+
+				// Already validated above.
+				edges, _ := cf.Topology().Edges(cf.Count())
+
+				t.Run(cf.Name(), func(t *testing.T) {
+					for _, rf := range rfs {
+						rf := rf
+
+						t.Run(rf.Name(), func(t *testing.T) {
+							rep.TrackParameters(t, rf.Labels(), cf.Labels())
+							rep.TrackParallel(t)
+
+							for _, e := range edges {
+								pair := cf.Pair(e[0], e[1])
+
+								t.Run(fmt.Sprintf("link %d-%d", e[0], e[1]), func(t *testing.T) {
+									rep.TrackTest(t)
+									rep.TrackParallel(t)
+
+									TestChainPair(t, pair, rf, rep)
+								})
+							}
+
+							t.Run("multi-hop", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerMultiHop(t, cf, rf, rep)
+							})
 						})
 					}
 				})
@@ -0,0 +1,102 @@
+package ibctest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCosmosChainUpgrade(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	const (
+		initialVersion = "v6.0.0"
+		upgradeVersion = "v8.0.0"
+		// upgradeName is the name of the upgrade handler registered by juno v8.
+		upgradeName = "multiverse"
+
+		// haltHeightDelta is the number of blocks after the proposal at which the chain halts,
+		// which must leave enough time for the proposal to pass.
+		haltHeightDelta = 20
+		votingPeriod    = "10s"
+		proposalDeposit = 10_000_000
+	)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+
+	const repository = "ghcr.io/strangelove-ventures/heighliner/juno"
+	cf := ibctest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*ibctest.ChainSpec{
+		{
+			Name: "juno", ChainName: "juno", Version: initialVersion,
+			ChainConfig: ibc.ChainConfig{
+				// The image to upgrade to must be configured along with the initial image.
+				Images: []ibc.DockerImage{
+					{Repository: repository},
+					{Repository: repository, Version: upgradeVersion},
+				},
+				ModifyGenesis: cosmos.ModifyGenesisSequence(
+					cosmos.ModifyGenesisProposalTime(votingPeriod, votingPeriod),
+					// Deposit and vote in the denom the faucet and validators hold.
+					cosmos.ModifyGenesisStakingBondDenom("ujuno"),
+				),
+			},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	juno := chains[0].(*cosmos.CosmosChain)
+
+	ic := ibctest.NewInterchain().AddChain(juno)
+	require.NoError(t, ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		HomeDir:   home,
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	height, err := juno.Height(ctx)
+	require.NoError(t, err)
+	haltHeight := height + haltHeightDelta
+
+	require.NoError(t, juno.Upgrade(ctx, ibctest.FaucetAccountKeyName, cosmos.SoftwareUpgradeProposal{
+		Deposit:     fmt.Sprintf("%d%s", proposalDeposit, juno.Config().Denom),
+		Title:       "Upgrade to " + upgradeVersion,
+		Name:        upgradeName,
+		Description: "Upgrade to " + upgradeVersion,
+		Height:      haltHeight,
+	}, upgradeVersion))
+
+	for _, n := range juno.ChainNodes {
+		require.Equal(t, upgradeVersion, n.Image.Version)
+	}
+
+	// The upgraded chain produces blocks past the halt height.
+	height, err = juno.Height(ctx)
+	require.NoError(t, err)
+	require.Greater(t, height, haltHeight)
+
+	// And accepts transactions.
+	users := ibctest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000, juno)
+	balance, err := juno.GetBalance(ctx, users[0].Bech32Address(juno.Config().Bech32Prefix), juno.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, int64(10_000_000), balance)
+}
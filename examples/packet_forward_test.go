@@ -79,21 +79,9 @@ func TestPacketForwardMiddleware(t *testing.T) {
 	require.NoError(t, err)
 
 	// Start the relayer on both paths
-	err = r.StartRelayer(ctx, eRep, pathOsmoHub)
+	err = ic.StartRelayers(t, ctx, eRep)
 	require.NoError(t, err)
 
-	err = r.StartRelayer(ctx, eRep, pathJunoHub)
-	require.NoError(t, err)
-
-	t.Cleanup(
-		func() {
-			err := r.StopRelayer(ctx, eRep)
-			if err != nil {
-				t.Logf("an error occured while stopping the relayer: %s", err)
-			}
-		},
-	)
-
 	// Get original account balances
 	osmosisUser := users[0]
 	gaiaUser := users[1]
@@ -105,6 +93,8 @@ func TestPacketForwardMiddleware(t *testing.T) {
 	// Send packet from Osmosis->Hub->Juno
 	// receiver format: {intermediate_refund_address}|{foward_port}/{forward_channel}:{final_destination_address}
 	const transferAmount int64 = 100000
+	// Number of blocks to wait for each step of a transfer through the hub
+	const traceBlocks = 10
 	receiver := fmt.Sprintf("%s|%s/%s:%s", gaiaUser.Bech32Address(gaia.Config().Bech32Prefix), channels[1].PortID, channels[1].ChannelID, junoUser.Bech32Address(juno.Config().Bech32Prefix))
 	transfer := ibc.WalletAmount{
 		Address: receiver,
@@ -112,12 +102,14 @@ func TestPacketForwardMiddleware(t *testing.T) {
 		Amount:  transferAmount,
 	}
 
-	_, err = osmosis.SendIBCTransfer(ctx, channels[0].ChannelID, osmosisUser.KeyName, transfer, nil)
+	tx, err := osmosis.SendIBCTransfer(ctx, channels[0].ChannelID, osmosisUser.KeyName, transfer, nil)
 	require.NoError(t, err)
 
-	// Wait for transfer to be relayed
-	err = test.WaitForBlocks(ctx, 10, gaia)
+	// Follow the transfer through the hub to juno, and the acknowledgements back
+	trace, err := ic.TracePacket(ctx, eRep, osmosis, tx, traceBlocks)
 	require.NoError(t, err)
+	require.Len(t, trace.Hops, 2)
+	require.Equal(t, juno, trace.Hops[1].Dst)
 
 	// Check that the funds sent are gone from the acc on osmosis
 	osmosisBal, err := osmosis.GetBalance(ctx, osmosisUser.Bech32Address(osmosis.Config().Bech32Prefix), osmosis.Config().Denom)
@@ -142,12 +134,13 @@ func TestPacketForwardMiddleware(t *testing.T) {
 		Amount:  transferAmount,
 	}
 
-	_, err = juno.SendIBCTransfer(ctx, channels[1].Counterparty.ChannelID, junoUser.KeyName, transfer, nil)
+	tx, err = juno.SendIBCTransfer(ctx, channels[1].Counterparty.ChannelID, junoUser.KeyName, transfer, nil)
 	require.NoError(t, err)
 
-	// Wait for transfer to be relayed
-	err = test.WaitForBlocks(ctx, 10, gaia)
+	trace, err = ic.TracePacket(ctx, eRep, juno, tx, traceBlocks)
 	require.NoError(t, err)
+	require.Len(t, trace.Hops, 2)
+	require.Equal(t, osmosis, trace.Hops[1].Dst)
 
 	// Check that the funds sent are gone from the acc on juno
 	junoBal, err = juno.GetBalance(ctx, junoUser.Bech32Address(juno.Config().Bech32Prefix), dstIbcDenom.IBCDenom())
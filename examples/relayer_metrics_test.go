@@ -0,0 +1,133 @@
+package ibctest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/relayer/rly"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// metricsRlyVersion is the first version of rly serving the metrics named in the rly package.
+const metricsRlyVersion = "v2.2.0"
+
+// reportBuffer collects the messages written by a testreporter.Reporter.
+type reportBuffer struct {
+	bytes.Buffer
+}
+
+func (*reportBuffer) Close() error {
+	return nil
+}
+
+func TestRelayerMetrics(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	report := new(reportBuffer)
+	rep := testreporter.NewReporter(report)
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+
+	cf := ibctest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*ibctest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia0, gaia1 := chains[0], chains[1]
+
+	r := ibctest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly, zaptest.NewLogger(t),
+		relayer.CustomDockerImage(rly.DefaultContainerImage, metricsRlyVersion),
+		relayer.EnableMetrics(),
+	).Build(t, client, network)
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(gaia0).
+		AddChain(gaia1).
+		AddRelayer(r, "relayer").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  gaia0,
+			Chain2:  gaia1,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	require.NoError(t, ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		HomeDir:   home,
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, ic.StartRelayers(t, ctx, eRep))
+
+	user := ibctest.GetAndFundTestUsers(t, ctx, "metrics", 10_000_000, gaia0)[0]
+	channel := ic.Link(r, pathName).Channels[0]
+	tx, err := gaia0.SendIBCTransfer(ctx, channel.ChannelID, user.KeyName, ibc.WalletAmount{
+		Address: user.Bech32Address(gaia1.Config().Bech32Prefix),
+		Denom:   gaia0.Config().Denom,
+		Amount:  10000,
+	}, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	_, err = test.PollForAck(ctx, gaia0, tx.Height, tx.Height+10, tx.Packet)
+	require.NoError(t, err)
+
+	// The acknowledgement was relayed, so rly submitted at least the receipt and the acknowledgement,
+	// paying fees on both chains.
+	dr, ok := r.(interface {
+		Metrics(context.Context) (relayer.Metrics, error)
+	})
+	require.True(t, ok, "relayer %T does not serve metrics", r)
+
+	// The metrics are updated once rly sees its transactions succeed, which may take a few more blocks.
+	var m relayer.Metrics
+	for i := 0; i < 10; i++ {
+		m, err = dr.Metrics(ctx)
+		require.NoError(t, err)
+		if m.Sum(rly.MetricRelayedPackets, nil) >= 2 {
+			break
+		}
+		require.NoError(t, test.WaitForBlocks(ctx, 1, gaia0))
+	}
+	require.GreaterOrEqual(t, m.Sum(rly.MetricRelayedPackets, nil), float64(2), "relayed packets not counted in metrics")
+	require.Positive(t, m.Sum(rly.MetricFeesSpent, map[string]string{"denom": gaia0.Config().Denom}))
+
+	// Stopping the relayer records its efficiency in the report.
+	require.NoError(t, ic.StopRelayers(ctx, eRep))
+	require.NoError(t, rep.Close())
+
+	var efficiency *testreporter.RelayerEfficiencyMessage
+	dec := json.NewDecoder(&report.Buffer)
+	for dec.More() {
+		var wm testreporter.WrappedMessage
+		require.NoError(t, dec.Decode(&wm))
+		if em, ok := wm.Message.(testreporter.RelayerEfficiencyMessage); ok {
+			efficiency = &em
+		}
+	}
+	require.NotNil(t, efficiency, "relayer efficiency not reported")
+	require.GreaterOrEqual(t, efficiency.PacketsRelayed, uint64(2))
+	require.Positive(t, efficiency.FeesSpent[gaia0.Config().Denom])
+}
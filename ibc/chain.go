@@ -3,6 +3,7 @@ package ibc
 import (
 	"context"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/docker/docker/client"
 )
 
@@ -95,4 +96,26 @@ type Chain interface {
 
 	// QueryInterchainAccount will query the interchain account that was created on behalf of the specified address.
 	QueryInterchainAccount(ctx context.Context, connectionID, address string) (string, error)
+
+	// SendICAMsg submits msg to be executed by the interchain account of fromAddr on the host chain of connectionID,
+	// and waits for up to maxBlocks for the acknowledgement or the timeout of the packet carrying it.
+	// The message's signer must be the interchain account address.
+	SendICAMsg(ctx context.Context, connectionID, fromAddr string, msg sdk.Msg, maxBlocks uint64) (ICAResult, error)
+
+	// QueryPacketCommitments returns the sequences of the packets sent on the channel
+	// whose commitments remain in the chain's state, i.e. which have not been acknowledged or timed out.
+	QueryPacketCommitments(ctx context.Context, portID, channelID string) ([]uint64, error)
+
+	// QueryPacketCommitment returns the commitment of the packet with the given sequence sent on the channel,
+	// or nil if the chain has no commitment for the packet.
+	QueryPacketCommitment(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error)
+
+	// QueryPacketReceipt reports whether the chain has a receipt of the packet with the given sequence
+	// received on the channel. Receipts are only written on unordered channels.
+	QueryPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error)
+
+	// QueryPacketAcknowledgement returns the commitment of the acknowledgement the chain wrote
+	// for the packet with the given sequence received on the channel,
+	// or nil if the chain has not acknowledged the packet.
+	QueryPacketAcknowledgement(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error)
 }
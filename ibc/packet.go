@@ -88,3 +88,26 @@ type PacketTimeout struct {
 func (timeout PacketTimeout) Validate() error {
 	return timeout.Packet.Validate()
 }
+
+// ICAResult is the outcome, on the host chain, of messages submitted through an interchain account,
+// as reported by the acknowledgement of the packet carrying them.
+type ICAResult struct {
+	// Packet carrying the messages from the controller chain to the host chain.
+	Packet Packet
+
+	// Result is the host chain's result of executing the messages, if they succeeded.
+	// For Cosmos SDK hosts, it is the protobuf encoding of the messages' sdk.TxMsgData.
+	Result []byte
+
+	// Error is the error acknowledgement of the host chain, if the messages failed.
+	Error string
+
+	// TimedOut is set if the packet timed out before the host chain received it,
+	// in which case the messages were not executed and, for an ordered channel, the channel is closed.
+	TimedOut bool
+}
+
+// Success reports whether the host chain executed the messages successfully.
+func (r ICAResult) Success() bool {
+	return !r.TimedOut && r.Error == ""
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	feetypes "github.com/cosmos/ibc-go/v4/modules/apps/29-fee/types"
+	transfertypes "github.com/cosmos/ibc-go/v4/modules/apps/transfer/types"
 	chantypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
 	ptypes "github.com/cosmos/ibc-go/v4/modules/core/05-port/types"
 	host "github.com/cosmos/ibc-go/v4/modules/core/24-host"
@@ -42,6 +44,13 @@ type Relayer interface {
 	// setup channels, connections, and clients
 	LinkPath(ctx context.Context, rep RelayerExecReporter, pathName string, opts CreateChannelOptions) error
 
+	// LinkExistingPath generates a new path between the chains of src and dst
+	// over the existing clients and connection identified by src and dst,
+	// such as those created by another relayer's LinkPath,
+	// so that the relayer relays the channels on that connection alongside the other relayer.
+	// It is used instead of GeneratePath and LinkPath.
+	LinkExistingPath(ctx context.Context, rep RelayerExecReporter, pathName string, src, dst PathEnd) error
+
 	// update clients, such as after new genesis
 	UpdateClients(ctx context.Context, rep RelayerExecReporter, pathName string) error
 
@@ -51,10 +60,10 @@ type Relayer interface {
 	// GetConnections returns a slice of IBC connection details composed of the details for each connection on a specified chain.
 	GetConnections(ctx context.Context, rep RelayerExecReporter, chainID string) (ConnectionOutputs, error)
 
-	// After configuration is initialized, begin relaying.
+	// After configuration is initialized, begin relaying on the given paths.
 	// This method is intended to create a background worker that runs the relayer.
 	// You must call StopRelayer to cleanly stop the relaying.
-	StartRelayer(ctx context.Context, rep RelayerExecReporter, pathName string) error
+	StartRelayer(ctx context.Context, rep RelayerExecReporter, pathNames ...string) error
 
 	// StopRelayer stops a relayer that started work through StartRelayer.
 	StopRelayer(ctx context.Context, rep RelayerExecReporter) error
@@ -76,6 +85,10 @@ type Relayer interface {
 	// CreateChannel creates a channel on the given path with the provided options.
 	CreateChannel(ctx context.Context, rep RelayerExecReporter, pathName string, opts CreateChannelOptions) error
 
+	// CloseChannel performs the channel closing handshake steps necessary to close
+	// the channel identified by portID and channelID on the src chain of the path, and its counterparty on dst.
+	CloseChannel(ctx context.Context, rep RelayerExecReporter, pathName, portID, channelID string) error
+
 	// UseDockerNetwork reports whether the relayer is run in the same docker network as the other chains.
 	//
 	// If false, the relayer will connect to the localhost-exposed ports instead of the docker hosts.
@@ -112,6 +125,23 @@ func DefaultChannelOpts() CreateChannelOptions {
 	}
 }
 
+// FeeEnabledVersion returns the channel version that negotiates the ICS-29 fee middleware
+// around appVersion, e.g. for the Version of CreateChannelOptions.
+func FeeEnabledVersion(appVersion string) string {
+	return string(feetypes.ModuleCdc.MustMarshalJSON(&feetypes.Metadata{
+		FeeVersion: feetypes.Version,
+		AppVersion: appVersion,
+	}))
+}
+
+// DefaultFeeChannelOpts returns the default settings for creating an ics20 fungible token transfer channel
+// with the ICS-29 fee middleware.
+func DefaultFeeChannelOpts() CreateChannelOptions {
+	opts := DefaultChannelOpts()
+	opts.Version = FeeEnabledVersion(opts.Version)
+	return opts
+}
+
 // Validate will check that the specified CreateChannelOptions are valid.
 func (opts CreateChannelOptions) Validate() error {
 	switch {
@@ -123,6 +153,9 @@ func (opts CreateChannelOptions) Validate() error {
 		return fmt.Errorf("invalid channel version")
 	case opts.Order.Validate() != nil:
 		return chantypes.ErrInvalidChannelOrdering
+	case (opts.SourcePortName == transfertypes.PortID || opts.DestPortName == transfertypes.PortID) && opts.Order != Unordered:
+		// The transfer module rejects channels that are not unordered.
+		return fmt.Errorf("transfer channels must be unordered: %w", chantypes.ErrInvalidChannelOrdering)
 	}
 	return nil
 }
@@ -192,3 +225,43 @@ type NopRelayerExecReporter struct{}
 
 func (NopRelayerExecReporter) TrackRelayerExec(string, []string, string, string, int, time.Time, time.Time, error) {
 }
+
+// RelayerLogReporter is optionally implemented by a RelayerExecReporter
+// to track the log lines of a long-running relayer, such as one started by StartRelayer,
+// in addition to the one-off commands tracked with TrackRelayerExec.
+type RelayerLogReporter interface {
+	TrackRelayerLog(
+		// The name of the docker container in which the relayer is running,
+		// or empty if it is not running in docker.
+		containerName string,
+
+		// When the line was logged.
+		when time.Time,
+
+		// The level, e.g. "info" or "error", and message of the line,
+		// and any structured fields logged with it.
+		// The level is empty and the message is the whole line if the line could not be parsed.
+		level, message string,
+		fields map[string]string,
+	)
+}
+
+// RelayerEfficiencyReporter is optionally implemented by a RelayerExecReporter
+// to track how much a long-running relayer, such as one started by StartRelayer, relayed
+// and what it spent doing so, as reported by the relayer's metrics.
+type RelayerEfficiencyReporter interface {
+	TrackRelayerEfficiency(
+		// The name of the docker container in which the relayer is running,
+		// or empty if it is not running in docker.
+		containerName string,
+
+		// When the relayer's metrics were read.
+		when time.Time,
+
+		// The number of packet messages, i.e. receipts, acknowledgements and timeouts, the relayer submitted.
+		packetsRelayed uint64,
+
+		// The fees the relayer's wallets paid for its transactions, keyed by denom.
+		feesSpent map[string]float64,
+	)
+}
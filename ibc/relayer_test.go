@@ -34,3 +34,11 @@ func TestChannelOptsConfigured(t *testing.T) {
 	}
 	require.Error(t, opts.Validate())
 }
+
+func TestFeeEnabledVersion(t *testing.T) {
+	require.Equal(t, `{"fee_version":"ics29-1","app_version":"ics20-1"}`, FeeEnabledVersion("ics20-1"))
+
+	opts := DefaultFeeChannelOpts()
+	require.NoError(t, opts.Validate())
+	require.Equal(t, FeeEnabledVersion("ics20-1"), opts.Version)
+}
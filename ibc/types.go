@@ -1,6 +1,10 @@
 package ibc
 
-import ibcexported "github.com/cosmos/ibc-go/v4/modules/core/03-connection/types"
+import (
+	"time"
+
+	ibcexported "github.com/cosmos/ibc-go/v4/modules/core/03-connection/types"
+)
 
 type ChainConfig struct {
 	Type           string
@@ -14,6 +18,15 @@ type ChainConfig struct {
 	GasAdjustment  float64
 	TrustingPeriod string
 	NoHostMount    bool
+	// ModifyGenesis, if set, is called with the chain's genesis file content
+	// after the genesis transactions are collected and before the genesis is distributed to every node.
+	// It returns the modified genesis file content.
+	// It may be called more than once while the chain starts, so it should be free of side effects.
+	ModifyGenesis func(ChainConfig, []byte) ([]byte, error)
+	// ValidatorConfig and FullNodeConfig override settings in the configuration files
+	// of the chain's validators and full nodes respectively.
+	ValidatorConfig NodeConfigOverrides
+	FullNodeConfig  NodeConfigOverrides
 }
 
 func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
@@ -59,6 +72,13 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 
 	// Skip NoHostMount so that false can be distinguished.
 
+	if other.ModifyGenesis != nil {
+		c.ModifyGenesis = other.ModifyGenesis
+	}
+
+	c.ValidatorConfig = c.ValidatorConfig.merge(other.ValidatorConfig)
+	c.FullNodeConfig = c.FullNodeConfig.merge(other.FullNodeConfig)
+
 	return c
 }
 
@@ -77,6 +97,44 @@ func (c ChainConfig) IsFullyConfigured() bool {
 		c.TrustingPeriod != ""
 }
 
+// NodeConfigOverrides holds settings to override in a node's config.toml and app.toml.
+// Keys are the names used in the files, and TOML tables are represented as nested maps,
+// e.g. {"consensus": {"timeout_commit": "1s"}} or {"pruning": "nothing"}.
+type NodeConfigOverrides struct {
+	ConfigToml map[string]interface{}
+	AppToml    map[string]interface{}
+}
+
+// merge returns a copy of o with the settings in other added,
+// replacing any top-level keys already present in o.
+func (o NodeConfigOverrides) merge(other NodeConfigOverrides) NodeConfigOverrides {
+	return NodeConfigOverrides{
+		ConfigToml: mergeOverrides(o.ConfigToml, other.ConfigToml),
+		AppToml:    mergeOverrides(o.AppToml, other.AppToml),
+	}
+}
+
+func mergeOverrides(a, b map[string]interface{}) map[string]interface{} {
+	if len(b) == 0 {
+		return a
+	}
+	m := make(map[string]interface{}, len(a)+len(b))
+	for k, v := range a {
+		m[k] = v
+	}
+	for k, v := range b {
+		m[k] = v
+	}
+	return m
+}
+
+// NetworkConditions describes degraded network behavior to apply to a container's outgoing traffic.
+type NetworkConditions struct {
+	Latency    time.Duration // delay added to every packet
+	Jitter     time.Duration // random variation in Latency; ignored if Latency is zero
+	PacketLoss float64       // percentage of packets dropped, from 0 to 100
+}
+
 type DockerImage struct {
 	Repository string
 	Version    string
@@ -138,6 +196,13 @@ type ConnectionOutput struct {
 
 type ConnectionOutputs []*ConnectionOutput
 
+// PathEnd identifies the client and connection on one chain of a path.
+type PathEnd struct {
+	ChainID      string
+	ClientID     string
+	ConnectionID string
+}
+
 type RelayerWallet struct {
 	Mnemonic string `json:"mnemonic"`
 	Address  string `json:"address"`
@@ -5,7 +5,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func TestDefaultBlockDatabaseFilepath(t *testing.T) {
@@ -15,3 +18,19 @@ func TestDefaultBlockDatabaseFilepath(t *testing.T) {
 	require.NotEmpty(t, parts)
 	require.Equal(t, []string{".ibctest", "databases", "block.db"}, parts[len(parts)-3:])
 }
+
+func TestInterchain_snapshotDir_Key(t *testing.T) {
+	c := cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "cosmoshub-0"}, 1, 0, zap.NewNop())
+	c.ChainNodes = cosmos.ChainNodes{&cosmos.ChainNode{}}
+	ic := NewInterchain().AddChain(c)
+
+	dir := func(key string) string {
+		d, err := ic.snapshotDir(InterchainBuildOptions{SnapshotDir: "/snapshots", SnapshotKey: key})
+		require.NoError(t, err)
+		return d
+	}
+
+	require.Equal(t, dir("genesis-a"), dir("genesis-a"))
+	require.NotEqual(t, dir("genesis-a"), dir("genesis-b"))
+	require.NotEqual(t, dir(""), dir("genesis-a"))
+}
@@ -3,14 +3,21 @@ package ibctest
 import (
 	"context"
 	"fmt"
+	"sync"
+	"testing"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/test"
 	"github.com/strangelove-ventures/ibctest/testreporter"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
 // Interchain represents a full IBC network, encompassing a collection of
@@ -27,15 +34,43 @@ type Interchain struct {
 	// Key: relayer and path name; Value: the two chains being linked.
 	links map[relayerPath][2]ibc.Chain
 
+	// Key: relayer and path name of a link sharing another relayer's path;
+	// Value: the relayer whose path of the same name is shared.
+	sharedLinks map[relayerPath]ibc.Relayer
+
+	// Key: relayer and path name; Value: the options of the channels created on the path,
+	// if the link overrides the options passed to Build.
+	channelOpts map[relayerPath][]ibc.CreateChannelOptions
+
+	// Relayers and path names of links, in the order they were added.
+	linkOrder []relayerPath
+
+	// Key: relayer and path name; Value: the ends of the path on the link's two chains, set during Build.
+	pathEnds map[relayerPath][2]ibc.PathEnd
+
+	// Key: relayer and path name; Value: the channels of the path as reported on the link's first chain,
+	// set during Build.
+	pathChannels map[relayerPath][]ibc.ChannelOutput
+
 	// Set to true after Build is called once.
 	built bool
 
 	// Map of relayer-chain pairs to address and mnemonic, set during Build().
-	// Not yet exposed through any exported API.
+	// Exposed through RelayerWallet and Links.
 	relayerWallets map[relayerChain]ibc.RelayerWallet
 
 	// Set during Build and cleaned up in the Close method.
 	cs *chainSet
+
+	// Relayers started by StartRelayers and the reporter they were started with,
+	// until they are stopped by StopRelayers or Close.
+	startedRelayers []ibc.Relayer
+	relayerRep      *testreporter.RelayerExecReporter
+
+	// Guards logsClosed, so that relayer log lines, which are streamed by goroutines
+	// that may outlive the interchain, are not saved once Close has closed the block database.
+	logMu      sync.RWMutex
+	logsClosed bool
 }
 
 // NewInterchain returns a new Interchain.
@@ -49,7 +84,12 @@ func NewInterchain() *Interchain {
 		chains:   make(map[ibc.Chain]string),
 		relayers: make(map[ibc.Relayer]string),
 
-		links: make(map[relayerPath][2]ibc.Chain),
+		links:       make(map[relayerPath][2]ibc.Chain),
+		sharedLinks: make(map[relayerPath]ibc.Relayer),
+		channelOpts: make(map[relayerPath][]ibc.CreateChannelOptions),
+
+		pathEnds:     make(map[relayerPath][2]ibc.PathEnd),
+		pathChannels: make(map[relayerPath][]ibc.ChannelOutput),
 	}
 }
 
@@ -118,6 +158,19 @@ type InterchainLink struct {
 
 	// Name of path to create.
 	Path string
+
+	// If set, the link does not create a new path,
+	// but shares the clients, connection, and channel of the path of the same name
+	// created by the ShareWith relayer, so that both relayers relay the same channel concurrently.
+	// The ShareWith relayer's link must be added first.
+	ShareWith ibc.Relayer
+
+	// If set, a channel is created on the path with each of these options, in order,
+	// instead of the single channel of InterchainBuildOptions.CreateChannelOpts.
+	// The first channel is created along with the path's clients and connection,
+	// and the others on the same connection.
+	// A link sharing another relayer's path must not set them.
+	CreateChannelOpts []ibc.CreateChannelOptions
 }
 
 // AddLink adds the given link to the Interchain.
@@ -148,7 +201,44 @@ func (ic *Interchain) AddLink(link InterchainLink) *Interchain {
 		panic(fmt.Errorf("relayer %q already has a path named %q", key.Relayer, key.Path))
 	}
 
+	if link.ShareWith != nil {
+		owner := relayerPath{
+			Relayer: link.ShareWith,
+			Path:    link.Path,
+		}
+		chains, exists := ic.links[owner]
+		if !exists || (chains != [2]ibc.Chain{link.Chain1, link.Chain2} && chains != [2]ibc.Chain{link.Chain2, link.Chain1}) {
+			panic(fmt.Errorf(
+				"relayer %s cannot share path %q of relayer %s, which does not link the same chains",
+				ic.relayers[link.Relayer], link.Path, ic.relayers[link.ShareWith],
+			))
+		}
+		if _, shared := ic.sharedLinks[owner]; shared {
+			panic(fmt.Errorf(
+				"relayer %s cannot share path %q of relayer %s, which is itself shared",
+				ic.relayers[link.Relayer], link.Path, ic.relayers[link.ShareWith],
+			))
+		}
+		if len(link.CreateChannelOpts) > 0 {
+			panic(fmt.Errorf(
+				"relayer %s cannot set channel options on path %q shared with relayer %s",
+				ic.relayers[link.Relayer], link.Path, ic.relayers[link.ShareWith],
+			))
+		}
+		ic.sharedLinks[key] = link.ShareWith
+	}
+
+	if len(link.CreateChannelOpts) > 0 {
+		for i, opts := range link.CreateChannelOpts {
+			if err := opts.Validate(); err != nil {
+				panic(fmt.Errorf("invalid options of channel %d for path %q of relayer %s: %w", i, link.Path, ic.relayers[link.Relayer], err))
+			}
+		}
+		ic.channelOpts[key] = append([]ibc.CreateChannelOptions(nil), link.CreateChannelOpts...)
+	}
+
 	ic.links[key] = [2]ibc.Chain{link.Chain1, link.Chain2}
+	ic.linkOrder = append(ic.linkOrder, key)
 	return ic
 }
 
@@ -165,7 +255,8 @@ type InterchainBuildOptions struct {
 	// This is useful for tests that need lower-level access to configuring relayers.
 	SkipPathCreation bool
 
-	// If set, these options will be used when creating the channel in the path link step.
+	// If set, these options will be used when creating the channel in the path link step,
+	// for links that do not set their own list of InterchainLink.CreateChannelOpts.
 	// If a zero value initialization is used, e.g. CreateChannelOptions{},
 	// then the default values will be used via ibc.DefaultChannelOpts.
 	CreateChannelOpts ibc.CreateChannelOptions
@@ -176,10 +267,29 @@ type InterchainBuildOptions struct {
 
 	// If set, saves block history to a sqlite3 database to aid debugging.
 	BlockDatabaseFile string
+
+	// If set, Build restores the Interchain from a snapshot in this directory,
+	// taken by the Build of an Interchain with the same chains, relayers, links and options,
+	// instead of starting the chains from genesis and creating the paths of the links.
+	// If there is no such snapshot yet, Build saves one once the paths are created.
+	//
+	// A snapshot holds an archive of the volume of every chain node, and the wallets and paths of the relayers;
+	// the relayers are configured again for the restored chains, whose host names depend on the test name.
+	// Only Cosmos chains can be snapshotted.
+	// The clients of a snapshot expire once it is older than their trusting period,
+	// so the directory should not outlive a test run.
+	SnapshotDir string
+
+	// SnapshotKey identifies what the ModifyGenesis functions of the chains do,
+	// since a snapshot cannot otherwise tell apart chains whose genesis is modified differently.
+	// It is part of the key of the snapshot in SnapshotDir,
+	// and it is required if SnapshotDir is set and any chain has a ModifyGenesis function.
+	SnapshotKey string
 }
 
 // Build starts all the chains and configures the relayers associated with the Interchain.
-// It is the caller's responsibility to directly call StartRelayer on the relayer implementations.
+// It does not start relaying; call StartRelayers,
+// or call StartRelayer directly on the relayer implementations.
 //
 // Calling Build more than once will cause a panic.
 func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecReporter, opts InterchainBuildOptions) error {
@@ -194,24 +304,61 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	}
 	ic.cs = newChainSet(ic.log, chains)
 
+	if opts.SnapshotDir != "" && opts.SnapshotKey == "" {
+		for c, name := range ic.chains {
+			if c.Config().ModifyGenesis != nil {
+				return fmt.Errorf("cannot snapshot chain %s with ModifyGenesis set without a SnapshotKey", name)
+			}
+		}
+	}
+
 	// Initialize the chains (pull docker images, etc.).
 	if err := ic.cs.Initialize(opts.TestName, opts.HomeDir, opts.Client, opts.NetworkID); err != nil {
 		return fmt.Errorf("failed to initialize chains: %w", err)
 	}
 
 	ic.generateRelayerWallets() // Build the relayer wallet mapping.
-	walletAmounts, err := ic.genesisWalletAmounts(ctx)
-	if err != nil {
-		// Error already wrapped with appropriate detail.
-		return err
+
+	var snapDir string
+	var snap *interchainSnapshot
+	if opts.SnapshotDir != "" {
+		var err error
+		snapDir, err = ic.snapshotDir(opts)
+		if err != nil {
+			return err
+		}
+		if snap, err = readSnapshot(snapDir); err != nil {
+			return err
+		}
 	}
 
-	if err := ic.cs.Start(ctx, opts.TestName, walletAmounts); err != nil {
-		return fmt.Errorf("failed to start chains: %w", err)
+	if snap != nil {
+		if err := ic.restoreRelayerWallets(snap); err != nil {
+			return err
+		}
+		if err := ic.restoreChains(ctx, snapDir); err != nil {
+			return err
+		}
+	} else {
+		walletAmounts, err := ic.genesisWalletAmounts(ctx)
+		if err != nil {
+			// Error already wrapped with appropriate detail.
+			return err
+		}
+
+		if err := ic.cs.Start(ctx, opts.TestName, walletAmounts); err != nil {
+			return fmt.Errorf("failed to start chains: %w", err)
+		}
 	}
 
-	if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha); err != nil {
-		return fmt.Errorf("failed to track blocks: %w", err)
+	// Saving a snapshot stops and restarts the chains, which block tracking would not survive,
+	// so in that case blocks are tracked once the snapshot is saved.
+	// The collectors start from the first block, so no blocks are missed.
+	saveSnap := snap == nil && opts.SnapshotDir != ""
+	if !saveSnap {
+		if err := ic.trackBlocks(ctx, opts); err != nil {
+			return err
+		}
 	}
 
 	if err := ic.configureRelayerKeys(ctx, rep); err != nil {
@@ -219,27 +366,71 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		return err
 	}
 
+	if snap != nil {
+		// The paths were created before the snapshot was taken.
+		return ic.linkSnapshotPaths(ctx, rep, snap)
+	}
+
 	// Some tests may want to configure the relayer from a lower level,
 	// but still have wallets configured.
-	if opts.SkipPathCreation {
-		return nil
+	if !opts.SkipPathCreation {
+		if err := ic.createPaths(ctx, rep, opts.CreateChannelOpts); err != nil {
+			return err
+		}
+	}
+
+	if saveSnap {
+		if err := ic.saveSnapshot(ctx, snapDir); err != nil {
+			return err
+		}
+		return ic.trackBlocks(ctx, opts)
+	}
+	return nil
+}
+
+// trackBlocks saves the blocks of the chains and the logs of the relayers in the block database, if one is set.
+func (ic *Interchain) trackBlocks(ctx context.Context, opts InterchainBuildOptions) error {
+	if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha); err != nil {
+		return fmt.Errorf("failed to track blocks: %w", err)
 	}
+	ic.trackRelayerLogs(ctx)
+	return nil
+}
 
+// createPaths creates the path of every link in its relayer, with the channels of the link,
+// or a single channel with the given options if the link has none.
+func (ic *Interchain) createPaths(ctx context.Context, rep *testreporter.RelayerExecReporter, createChannelOpts ibc.CreateChannelOptions) error {
 	// If the user specifies a zero value CreateChannelOptions struct then we fall back to the default
 	// channel options for an ics20 fungible token transfer channel.
-	if opts.CreateChannelOpts == (ibc.CreateChannelOptions{}) {
-		opts.CreateChannelOpts = ibc.DefaultChannelOpts()
+	if createChannelOpts == (ibc.CreateChannelOptions{}) {
+		createChannelOpts = ibc.DefaultChannelOpts()
 	}
 
 	// Check that the channel creation options are valid and fully specified.
-	if err := opts.CreateChannelOpts.Validate(); err != nil {
+	if err := createChannelOpts.Validate(); err != nil {
 		return err
 	}
 
 	// For every relayer link, teach the relayer about the link and create the link.
 	for rp, chains := range ic.links {
+		if _, ok := ic.sharedLinks[rp]; ok {
+			// Linked below, once the shared path exists.
+			continue
+		}
+
 		c0 := chains[0]
 		c1 := chains[1]
+
+		// The path's connection and channels are found by comparing the chain's state before and after they are created.
+		prevConns, err := rp.Relayer.GetConnections(ctx, rep, c0.Config().ChainID)
+		if err != nil {
+			return fmt.Errorf("failed to get connections on chain %s from relayer %s: %w", ic.chains[c0], rp.Relayer, err)
+		}
+		prevChannels, err := rp.Relayer.GetChannels(ctx, rep, c0.Config().ChainID)
+		if err != nil {
+			return fmt.Errorf("failed to get channels on chain %s from relayer %s: %w", ic.chains[c0], rp.Relayer, err)
+		}
+
 		if err := rp.Relayer.GeneratePath(ctx, rep, c0.Config().ChainID, c1.Config().ChainID, rp.Path); err != nil {
 			return fmt.Errorf(
 				"failed to generate path %s on relayer %s between chains %s and %s: %w",
@@ -247,17 +438,337 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 			)
 		}
 
-		if err := rp.Relayer.LinkPath(ctx, rep, rp.Path, opts.CreateChannelOpts); err != nil {
+		channelOpts := []ibc.CreateChannelOptions{createChannelOpts}
+		if linkOpts, ok := ic.channelOpts[rp]; ok {
+			channelOpts = linkOpts
+		}
+
+		if err := rp.Relayer.LinkPath(ctx, rep, rp.Path, channelOpts[0]); err != nil {
 			return fmt.Errorf(
 				"failed to link path %s on relayer %s between chains %s and %s: %w",
 				rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
 			)
 		}
+
+		ends, err := newPathEnds(ctx, rep, rp.Relayer, c0, c1, prevConns)
+		if err != nil {
+			return fmt.Errorf("failed to find connection of path %s on relayer %s: %w", rp.Path, rp.Relayer, err)
+		}
+		ic.pathEnds[rp] = ends
+
+		channel, prevChannels, err := newChannel(ctx, rep, rp.Relayer, c0, ends[0].ConnectionID, prevChannels)
+		if err != nil {
+			return fmt.Errorf("failed to find channel 0 of path %s on relayer %s: %w", rp.Path, rp.Relayer, err)
+		}
+		channels := []ibc.ChannelOutput{channel}
+
+		// Further channels reuse the connection created by LinkPath.
+		for i, chOpts := range channelOpts[1:] {
+			if err := rp.Relayer.CreateChannel(ctx, rep, rp.Path, chOpts); err != nil {
+				return fmt.Errorf(
+					"failed to create channel %d (ports %s/%s) of path %s on relayer %s between chains %s and %s: %w",
+					i+1, chOpts.SourcePortName, chOpts.DestPortName, rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
+				)
+			}
+
+			channel, prevChannels, err = newChannel(ctx, rep, rp.Relayer, c0, ends[0].ConnectionID, prevChannels)
+			if err != nil {
+				return fmt.Errorf("failed to find channel %d of path %s on relayer %s: %w", i+1, rp.Path, rp.Relayer, err)
+			}
+			channels = append(channels, channel)
+		}
+		ic.pathChannels[rp] = channels
+	}
+
+	// Then teach the relayers sharing a path about the clients and connection of that path.
+	for rp, owner := range ic.sharedLinks {
+		ownerPath := relayerPath{Relayer: owner, Path: rp.Path}
+		ends := ic.pathEnds[ownerPath]
+		src, dst := ends[0], ends[1]
+		channels := ic.pathChannels[ownerPath]
+		if ic.links[rp][0] != ic.links[ownerPath][0] {
+			// The link was declared with the chains in the opposite order.
+			src, dst = dst, src
+			channels = counterpartyChannels(channels, src.ConnectionID)
+		}
+		ic.pathEnds[rp] = [2]ibc.PathEnd{src, dst}
+		ic.pathChannels[rp] = channels
+
+		if err := rp.Relayer.LinkExistingPath(ctx, rep, rp.Path, src, dst); err != nil {
+			return fmt.Errorf(
+				"failed to link path %s on relayer %s, shared with relayer %s, between chains %s and %s: %w",
+				rp.Path, rp.Relayer, owner, src.ChainID, dst.ChainID, err,
+			)
+		}
 	}
 
 	return nil
 }
 
+// BuiltLink is the state of a link of an Interchain after Build:
+// the identifiers of the clients, connection, and channels of its path, and the wallets of its relayer.
+type BuiltLink struct {
+	Chain1, Chain2 ibc.Chain
+	Relayer        ibc.Relayer
+	Path           string
+
+	// Clients and connection of the path on Chain1 and Chain2.
+	// Empty if Build skipped path creation.
+	End1, End2 ibc.PathEnd
+
+	// Channels of the path, in the order of the link's channel options, as reported on Chain1.
+	// The Counterparty of each channel is its end on Chain2.
+	// Empty if Build skipped path creation.
+	Channels []ibc.ChannelOutput
+
+	// Wallets of the relayer on Chain1 and Chain2.
+	Wallet1, Wallet2 ibc.RelayerWallet
+}
+
+// Link returns the state of the path named pathName of relayer r.
+// It panics if Build has not been called, or if the relayer has no link with that path.
+func (ic *Interchain) Link(r ibc.Relayer, pathName string) BuiltLink {
+	if !ic.built {
+		panic(fmt.Errorf("Interchain.Link called before Build"))
+	}
+	rp := relayerPath{Relayer: r, Path: pathName}
+	chains, ok := ic.links[rp]
+	if !ok {
+		panic(fmt.Errorf("relayer %s has no path named %q", ic.relayers[r], pathName))
+	}
+
+	ends := ic.pathEnds[rp]
+	return BuiltLink{
+		Chain1:  chains[0],
+		Chain2:  chains[1],
+		Relayer: r,
+		Path:    pathName,
+
+		End1: ends[0],
+		End2: ends[1],
+
+		Channels: append([]ibc.ChannelOutput(nil), ic.pathChannels[rp]...),
+
+		Wallet1: ic.relayerWallets[relayerChain{R: r, C: chains[0]}],
+		Wallet2: ic.relayerWallets[relayerChain{R: r, C: chains[1]}],
+	}
+}
+
+// Links returns the state of every link, in the order the links were added.
+// It panics if Build has not been called.
+func (ic *Interchain) Links() []BuiltLink {
+	if !ic.built {
+		panic(fmt.Errorf("Interchain.Links called before Build"))
+	}
+	links := make([]BuiltLink, len(ic.linkOrder))
+	for i, rp := range ic.linkOrder {
+		links[i] = ic.Link(rp.Relayer, rp.Path)
+	}
+	return links
+}
+
+// RelayerWallet returns the wallet of relayer r on chain c, created during Build,
+// and whether r relays any link of c.
+func (ic *Interchain) RelayerWallet(r ibc.Relayer, c ibc.Chain) (ibc.RelayerWallet, bool) {
+	w, ok := ic.relayerWallets[relayerChain{R: r, C: c}]
+	return w, ok
+}
+
+// readyWaiter is implemented by relayers that report when they have started relaying,
+// such as *relayer.DockerRelayer.
+type readyWaiter interface {
+	WaitUntilReady(ctx context.Context) error
+}
+
+// relayerReadyTimeout is how long StartRelayers waits for the relayers to start relaying.
+const relayerReadyTimeout = 2 * time.Minute
+
+// StartRelayers starts every relayer on all the paths of its links, including the paths it shares,
+// and waits until each relayer has started relaying.
+// A relayer implementing WaitUntilReady, such as *relayer.DockerRelayer, is ready once that returns;
+// any other relayer is considered ready once the chains it links have produced two blocks since it started.
+//
+// The relayers are stopped by StopRelayers, by Close, or else when t's cleanup runs.
+// If starting or waiting for a relayer fails, the relayers already started are left running until then.
+//
+// StartRelayers panics if Build has not been called.
+func (ic *Interchain) StartRelayers(t *testing.T, ctx context.Context, rep *testreporter.RelayerExecReporter) error {
+	if !ic.built {
+		panic(fmt.Errorf("Interchain.StartRelayers called before Build"))
+	}
+	if len(ic.startedRelayers) > 0 {
+		return fmt.Errorf("relayers already started")
+	}
+	ic.relayerRep = rep
+
+	t.Cleanup(func() {
+		// Nothing to do if the relayers were already stopped by StopRelayers or Close.
+		if len(ic.startedRelayers) == 0 {
+			return
+		}
+		if err := ic.StopRelayers(context.Background(), rep); err != nil {
+			t.Logf("error stopping relayers: %v", err)
+		}
+	})
+
+	// Paths of each relayer, and the relayers in the order their first link was added.
+	var relayers []ibc.Relayer
+	paths := make(map[ibc.Relayer][]string)
+	for _, rp := range ic.linkOrder {
+		if _, ok := paths[rp.Relayer]; !ok {
+			relayers = append(relayers, rp.Relayer)
+		}
+		paths[rp.Relayer] = append(paths[rp.Relayer], rp.Path)
+	}
+
+	for _, r := range relayers {
+		if err := r.StartRelayer(ctx, rep, paths[r]...); err != nil {
+			return fmt.Errorf("failed to start relayer %s on paths %v: %w", ic.relayers[r], paths[r], err)
+		}
+		ic.startedRelayers = append(ic.startedRelayers, r)
+	}
+
+	readyCtx, cancel := context.WithTimeout(ctx, relayerReadyTimeout)
+	defer cancel()
+	relayerChains := ic.relayerChains()
+	eg, egCtx := errgroup.WithContext(readyCtx)
+	for _, r := range relayers {
+		r := r
+		eg.Go(func() error {
+			if rw, ok := r.(readyWaiter); ok {
+				if err := rw.WaitUntilReady(egCtx); err != nil {
+					return fmt.Errorf("relayer %s did not start relaying: %w", ic.relayers[r], err)
+				}
+				return nil
+			}
+
+			chains := relayerChains[r]
+			heighters := make([]test.ChainHeighter, len(chains))
+			for i, c := range chains {
+				heighters[i] = c
+			}
+			if err := test.WaitForBlocks(egCtx, 2, heighters...); err != nil {
+				return fmt.Errorf("failed to wait for blocks after starting relayer %s: %w", ic.relayers[r], err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// StopRelayers stops the relayers started by StartRelayers.
+func (ic *Interchain) StopRelayers(ctx context.Context, rep *testreporter.RelayerExecReporter) error {
+	var err error
+	for _, r := range ic.startedRelayers {
+		if stopErr := r.StopRelayer(ctx, rep); stopErr != nil {
+			multierr.AppendInto(&err, fmt.Errorf("failed to stop relayer %s: %w", ic.relayers[r], stopErr))
+		}
+	}
+	ic.startedRelayers = nil
+	return err
+}
+
+// newPathEnds returns the ends of the connection between c0 and c1
+// that r created since it reported prevConns on c0.
+func newPathEnds(ctx context.Context, rep *testreporter.RelayerExecReporter, r ibc.Relayer, c0, c1 ibc.Chain, prevConns ibc.ConnectionOutputs) ([2]ibc.PathEnd, error) {
+	conns, err := r.GetConnections(ctx, rep, c0.Config().ChainID)
+	if err != nil {
+		return [2]ibc.PathEnd{}, err
+	}
+
+	prev := make(map[string]bool, len(prevConns))
+	for _, conn := range prevConns {
+		prev[conn.ID] = true
+	}
+	for _, conn := range conns {
+		if prev[conn.ID] || conn.Counterparty == nil {
+			continue
+		}
+		return [2]ibc.PathEnd{
+			{ChainID: c0.Config().ChainID, ClientID: conn.ClientID, ConnectionID: conn.ID},
+			{ChainID: c1.Config().ChainID, ClientID: conn.Counterparty.ClientId, ConnectionID: conn.Counterparty.ConnectionId},
+		}, nil
+	}
+	return [2]ibc.PathEnd{}, fmt.Errorf("no new connection on chain %s", c0.Config().ChainID)
+}
+
+// newChannel returns the channel of connectionID on c that r reports, but did not report in prev,
+// along with all the channels r reports on c.
+func newChannel(
+	ctx context.Context,
+	rep *testreporter.RelayerExecReporter,
+	r ibc.Relayer,
+	c ibc.Chain,
+	connectionID string,
+	prev []ibc.ChannelOutput,
+) (ibc.ChannelOutput, []ibc.ChannelOutput, error) {
+	channels, err := r.GetChannels(ctx, rep, c.Config().ChainID)
+	if err != nil {
+		return ibc.ChannelOutput{}, nil, err
+	}
+
+	seen := make(map[string]bool, len(prev))
+	for _, ch := range prev {
+		seen[ch.PortID+"/"+ch.ChannelID] = true
+	}
+	for _, ch := range channels {
+		if seen[ch.PortID+"/"+ch.ChannelID] || len(ch.ConnectionHops) == 0 || ch.ConnectionHops[0] != connectionID {
+			continue
+		}
+		return ch, channels, nil
+	}
+	return ibc.ChannelOutput{}, nil, fmt.Errorf("no new channel on connection %s of chain %s", connectionID, c.Config().ChainID)
+}
+
+// counterpartyChannels returns the counterparty ends of channels,
+// which are on connectionID of the counterparty chain.
+func counterpartyChannels(channels []ibc.ChannelOutput, connectionID string) []ibc.ChannelOutput {
+	out := make([]ibc.ChannelOutput, len(channels))
+	for i, ch := range channels {
+		out[i] = ibc.ChannelOutput{
+			State:          ch.State,
+			Ordering:       ch.Ordering,
+			Counterparty:   ibc.ChannelCounterparty{PortID: ch.PortID, ChannelID: ch.ChannelID},
+			ConnectionHops: []string{connectionID},
+			Version:        ch.Version,
+			PortID:         ch.Counterparty.PortID,
+			ChannelID:      ch.Counterparty.ChannelID,
+		}
+	}
+	return out
+}
+
+// logSinkAdder is implemented by relayers whose logs can be streamed, such as *relayer.DockerRelayer.
+type logSinkAdder interface {
+	AddLogSink(relayer.LogSink)
+}
+
+// trackRelayerLogs saves the logs of the relayers started with StartRelayer in the block database,
+// if blocks are being tracked.
+func (ic *Interchain) trackRelayerLogs(ctx context.Context) {
+	tc := ic.cs.testCase
+	if tc == nil {
+		return
+	}
+	for r := range ic.relayers {
+		lr, ok := r.(logSinkAdder)
+		if !ok {
+			continue
+		}
+		lr.AddLogSink(func(containerName string, line relayer.LogLine) {
+			ic.logMu.RLock()
+			defer ic.logMu.RUnlock()
+			if ic.logsClosed {
+				return
+			}
+
+			if err := tc.SaveRelayerLog(ctx, containerName, line.Time, line.Level, line.Message, line.Fields); err != nil {
+				ic.log.Info("Failed to save relayer log", zap.String("container", containerName), zap.Error(err))
+			}
+		})
+	}
+}
+
 // WithLog sets the logger on the interchain object.
 // Usually the default nop logger is fine, but sometimes it can be helpful
 // to see more verbose logs, typically by passing zaptest.NewLogger(t).
@@ -267,9 +778,21 @@ func (ic *Interchain) WithLog(log *zap.Logger) *Interchain {
 }
 
 // Close cleans up any resources created during Build,
+// stops any relayers still running since StartRelayers,
 // and returns any relevant errors.
 func (ic *Interchain) Close() error {
-	return ic.cs.Close()
+	var err error
+	if len(ic.startedRelayers) > 0 {
+		multierr.AppendInto(&err, ic.StopRelayers(context.Background(), ic.relayerRep))
+	}
+
+	// Relayers started directly may still be logging; wait for lines being saved, and drop later ones.
+	ic.logMu.Lock()
+	ic.logsClosed = true
+	ic.logMu.Unlock()
+
+	multierr.AppendInto(&err, ic.cs.Close())
+	return err
 }
 
 func (ic *Interchain) genesisWalletAmounts(ctx context.Context) (map[ibc.Chain][]ibc.WalletAmount, error) {
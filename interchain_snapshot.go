@@ -0,0 +1,385 @@
+package ibctest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/internal/dockerutil"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// snapshotFormatVersion is part of the key of every snapshot,
+// so that snapshots taken by an incompatible version of ibctest are not restored.
+const snapshotFormatVersion = 1
+
+// snapshotManifestFile is the name of the file describing a snapshot,
+// written once the archives of the node volumes are complete.
+const snapshotManifestFile = "manifest.json"
+
+// snapshotSpec is everything determining the state of an Interchain after Build.
+// The directory of a snapshot is named after a hash of the spec of the Interchain it was taken from.
+type snapshotSpec struct {
+	Version int
+
+	// Key is the caller's InterchainBuildOptions.SnapshotKey.
+	Key string
+
+	Chains   []snapshotChainSpec
+	Relayers []string
+	Links    []snapshotLinkSpec
+
+	SkipPathCreation  bool
+	CreateChannelOpts ibc.CreateChannelOptions
+}
+
+type snapshotChainSpec struct {
+	Name string
+
+	Type, ChainName, ChainID string
+	Images                   []ibc.DockerImage
+
+	Bin, Bech32Prefix, Denom, GasPrices string
+	GasAdjustment                       float64
+	TrustingPeriod                      string
+	NoHostMount                         bool
+
+	// The effect of ModifyGenesis cannot be known, only whether it is set;
+	// the caller's snapshot key identifies what it does.
+	ModifyGenesis bool
+
+	ValidatorConfig, FullNodeConfig ibc.NodeConfigOverrides
+
+	NumValidators, NumFullNodes int
+}
+
+type snapshotLinkSpec struct {
+	Chain1, Chain2 string
+	Relayer, Path  string
+	ShareWith      string
+
+	CreateChannelOpts []ibc.CreateChannelOptions
+}
+
+// interchainSnapshot is the manifest of a snapshot,
+// saved alongside an archive of the volume of every node of every chain.
+type interchainSnapshot struct {
+	// Wallets of the relayers, keyed by relayer name and then chain name.
+	RelayerWallets map[string]map[string]ibc.RelayerWallet
+
+	// Paths of the links, in the order the links were added.
+	Links []snapshotLink
+}
+
+type snapshotLink struct {
+	Relayer, Path string
+
+	Ends     [2]ibc.PathEnd
+	Channels []ibc.ChannelOutput
+}
+
+// snapshotChains returns the chains of the Interchain as Cosmos chains, keyed by name,
+// since only their node volumes can be saved and restored.
+func (ic *Interchain) snapshotChains() (map[string]*cosmos.CosmosChain, error) {
+	chains := make(map[string]*cosmos.CosmosChain, len(ic.chains))
+	for c, name := range ic.chains {
+		cc, ok := c.(*cosmos.CosmosChain)
+		if !ok {
+			return nil, fmt.Errorf("cannot snapshot chain %s of type %T", name, c)
+		}
+		chains[name] = cc
+	}
+	return chains, nil
+}
+
+// snapshotDir returns the directory, within opts.SnapshotDir, of the snapshot of the Interchain.
+// It must be called after the chains are initialized, since the number of nodes of each chain is part of the key.
+func (ic *Interchain) snapshotDir(opts InterchainBuildOptions) (string, error) {
+	chains, err := ic.snapshotChains()
+	if err != nil {
+		return "", err
+	}
+
+	spec := snapshotSpec{
+		Version: snapshotFormatVersion,
+		Key:     opts.SnapshotKey,
+
+		SkipPathCreation:  opts.SkipPathCreation,
+		CreateChannelOpts: opts.CreateChannelOpts,
+	}
+	if spec.CreateChannelOpts == (ibc.CreateChannelOptions{}) {
+		spec.CreateChannelOpts = ibc.DefaultChannelOpts()
+	}
+
+	for name, c := range chains {
+		cfg := c.Config()
+		spec.Chains = append(spec.Chains, snapshotChainSpec{
+			Name: name,
+
+			Type:      cfg.Type,
+			ChainName: cfg.Name,
+			ChainID:   cfg.ChainID,
+			Images:    cfg.Images,
+
+			Bin:            cfg.Bin,
+			Bech32Prefix:   cfg.Bech32Prefix,
+			Denom:          cfg.Denom,
+			GasPrices:      cfg.GasPrices,
+			GasAdjustment:  cfg.GasAdjustment,
+			TrustingPeriod: cfg.TrustingPeriod,
+			NoHostMount:    cfg.NoHostMount,
+
+			ModifyGenesis: cfg.ModifyGenesis != nil,
+
+			ValidatorConfig: cfg.ValidatorConfig,
+			FullNodeConfig:  cfg.FullNodeConfig,
+
+			NumValidators: len(c.Validators()),
+			NumFullNodes:  len(c.FullNodes()),
+		})
+	}
+	sort.Slice(spec.Chains, func(i, j int) bool { return spec.Chains[i].Name < spec.Chains[j].Name })
+
+	for _, name := range ic.relayers {
+		spec.Relayers = append(spec.Relayers, name)
+	}
+	sort.Strings(spec.Relayers)
+
+	for _, rp := range ic.linkOrder {
+		chains := ic.links[rp]
+		l := snapshotLinkSpec{
+			Chain1:  ic.chains[chains[0]],
+			Chain2:  ic.chains[chains[1]],
+			Relayer: ic.relayers[rp.Relayer],
+			Path:    rp.Path,
+
+			CreateChannelOpts: ic.channelOpts[rp],
+		}
+		if owner, ok := ic.sharedLinks[rp]; ok {
+			l.ShareWith = ic.relayers[owner]
+		}
+		spec.Links = append(spec.Links, l)
+	}
+
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode snapshot spec: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return filepath.Join(opts.SnapshotDir, hex.EncodeToString(sum[:16])), nil
+}
+
+// readSnapshot returns the manifest of the snapshot in dir,
+// or nil if there is no complete snapshot in dir.
+func readSnapshot(dir string) (*interchainSnapshot, error) {
+	b, err := os.ReadFile(filepath.Join(dir, snapshotManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot manifest: %w", err)
+	}
+
+	var snap interchainSnapshot
+	if err := json.Unmarshal(b, &snap); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot manifest in %s: %w", dir, err)
+	}
+	return &snap, nil
+}
+
+// nodeArchiveFile is the name of the archive of the volume of the node with index i of the named chain.
+func nodeArchiveFile(chainName string, i int) string {
+	return fmt.Sprintf("%s-node-%d.tar", chainName, i)
+}
+
+// restoreChains restores the volume of every node of every chain from the snapshot in dir,
+// and starts the chains from that state.
+func (ic *Interchain) restoreChains(ctx context.Context, dir string) error {
+	chains, err := ic.snapshotChains()
+	if err != nil {
+		return err
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for name, c := range chains {
+		name, c := name, c
+		eg.Go(func() error {
+			for i, n := range c.ChainNodes {
+				a := dockerutil.NewVolumeArchiver(ic.log, n.DockerClient, n.TestName)
+				if err := restoreVolume(egCtx, a, n.VolumeName, filepath.Join(dir, nodeArchiveFile(name, i))); err != nil {
+					return fmt.Errorf("failed to restore node %d of chain %s: %w", i, name, err)
+				}
+			}
+
+			if err := c.StartRestoredNodes(egCtx); err != nil {
+				return fmt.Errorf("failed to start restored chain %s: %w", name, err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+func restoreVolume(ctx context.Context, a *dockerutil.VolumeArchiver, volumeName, archivePath string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return a.Restore(ctx, volumeName, f)
+}
+
+// restoreRelayerWallets replaces the relayer wallets generated by Build with those of the snapshot,
+// which were funded in the genesis of the restored chains.
+func (ic *Interchain) restoreRelayerWallets(snap *interchainSnapshot) error {
+	for rc := range ic.relayerWallets {
+		w, ok := snap.RelayerWallets[ic.relayers[rc.R]][ic.chains[rc.C]]
+		if !ok {
+			return fmt.Errorf("snapshot has no wallet of relayer %s on chain %s", ic.relayers[rc.R], ic.chains[rc.C])
+		}
+		ic.relayerWallets[rc] = w
+	}
+	return nil
+}
+
+// linkSnapshotPaths teaches every relayer about the clients and connections of its paths,
+// created before the snapshot was taken.
+func (ic *Interchain) linkSnapshotPaths(ctx context.Context, rep *testreporter.RelayerExecReporter, snap *interchainSnapshot) error {
+	relayers := make(map[string]ibc.Relayer, len(ic.relayers))
+	for r, name := range ic.relayers {
+		relayers[name] = r
+	}
+
+	for _, l := range snap.Links {
+		rp := relayerPath{Relayer: relayers[l.Relayer], Path: l.Path}
+		if _, ok := ic.links[rp]; !ok {
+			return fmt.Errorf("snapshot has path %s of relayer %s, which is not a link", l.Path, l.Relayer)
+		}
+		ic.pathEnds[rp] = l.Ends
+		ic.pathChannels[rp] = l.Channels
+
+		if err := rp.Relayer.LinkExistingPath(ctx, rep, rp.Path, l.Ends[0], l.Ends[1]); err != nil {
+			return fmt.Errorf(
+				"failed to link restored path %s on relayer %s between chains %s and %s: %w",
+				rp.Path, l.Relayer, l.Ends[0].ChainID, l.Ends[1].ChainID, err,
+			)
+		}
+	}
+	return nil
+}
+
+// saveSnapshot saves the volume of every node of every chain, with the relayer wallets and paths, to dir.
+// The chains are stopped while their volumes are archived, and started again afterwards.
+//
+// The snapshot is written to a temporary directory which is then renamed to dir,
+// so a concurrent test building the same Interchain either sees no snapshot or a complete one.
+// If another test saved the snapshot first, this one is discarded.
+func (ic *Interchain) saveSnapshot(ctx context.Context, dir string) error {
+	chains, err := ic.snapshotChains()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp(filepath.Dir(dir), ".tmp-"+filepath.Base(dir)+"-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary snapshot directory: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for name, c := range chains {
+		name, c := name, c
+		eg.Go(func() error {
+			if err := c.StopAllNodes(egCtx); err != nil {
+				return fmt.Errorf("failed to stop chain %s: %w", name, err)
+			}
+
+			for i, n := range c.ChainNodes {
+				a := dockerutil.NewVolumeArchiver(ic.log, n.DockerClient, n.TestName)
+				if err := archiveVolume(egCtx, a, n.VolumeName, filepath.Join(tmpDir, nodeArchiveFile(name, i))); err != nil {
+					return fmt.Errorf("failed to archive node %d of chain %s: %w", i, name, err)
+				}
+			}
+
+			if err := c.StartAllNodes(egCtx); err != nil {
+				return fmt.Errorf("failed to restart chain %s: %w", name, err)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	snap := interchainSnapshot{
+		RelayerWallets: make(map[string]map[string]ibc.RelayerWallet, len(ic.relayers)),
+	}
+	for rc, w := range ic.relayerWallets {
+		rName := ic.relayers[rc.R]
+		if snap.RelayerWallets[rName] == nil {
+			snap.RelayerWallets[rName] = make(map[string]ibc.RelayerWallet)
+		}
+		snap.RelayerWallets[rName][ic.chains[rc.C]] = w
+	}
+	for _, rp := range ic.linkOrder {
+		ends, ok := ic.pathEnds[rp]
+		if !ok {
+			// Path creation was skipped.
+			continue
+		}
+		snap.Links = append(snap.Links, snapshotLink{
+			Relayer: ic.relayers[rp.Relayer],
+			Path:    rp.Path,
+
+			Ends:     ends,
+			Channels: ic.pathChannels[rp],
+		})
+	}
+
+	b, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, snapshotManifestFile), b, 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+
+	if err := os.Rename(tmpDir, dir); err != nil {
+		if _, statErr := os.Stat(filepath.Join(dir, snapshotManifestFile)); statErr == nil {
+			ic.log.Info("Discarding snapshot saved concurrently by another test", zap.String("dir", dir))
+			return nil
+		}
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	ic.log.Info("Saved interchain snapshot", zap.String("dir", dir))
+	return nil
+}
+
+func archiveVolume(ctx context.Context, a *dockerutil.VolumeArchiver, volumeName, archivePath string) error {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Archive(ctx, volumeName, f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
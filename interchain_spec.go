@@ -0,0 +1,415 @@
+package ibctest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// InterchainSpec is a declarative description of an Interchain,
+// so that scenarios can be written as YAML or JSON files instead of Go code.
+// Use ReadInterchainSpec or ParseInterchainSpec to load one,
+// and its Interchain method to build the Interchain it describes.
+//
+// Field names are matched case-insensitively, e.g. "chains" or "Chains".
+type InterchainSpec struct {
+	Chains   []InterchainChainSpec
+	Relayers []InterchainRelayerSpec
+	Links    []InterchainLinkSpec
+
+	// Users created and funded on the chains once the Interchain is built.
+	Users []InterchainUserSpec
+}
+
+// InterchainChainSpec is a chain of an InterchainSpec.
+type InterchainChainSpec struct {
+	// Handle names the chain in the links and users of the spec,
+	// and in InterchainHandles.Chains.
+	// Defaults to the chain ID.
+	Handle string
+
+	// The fields of the ChainSpec are set inline, beside Handle.
+	*ChainSpec
+}
+
+// InterchainRelayerSpec is a relayer of an InterchainSpec.
+type InterchainRelayerSpec struct {
+	// Name of the relayer, used in the links of the spec and in InterchainHandles.Relayers.
+	Name string
+
+	// Type of relayer, "rly" or "hermes".
+	Type string
+
+	// Optional Docker image overriding the relayer's default image.
+	Image *ibc.DockerImage
+
+	// If set, overrides whether the relayer image is pulled.
+	Pull *bool
+
+	// Extra flags passed to the relayer when it is started.
+	StartFlags []string
+
+	// If set, the relayer serves its Prometheus metrics while it runs.
+	Metrics bool
+}
+
+// InterchainLinkSpec is a link of an InterchainSpec.
+type InterchainLinkSpec struct {
+	// Handles of the linked chains.
+	Chain1, Chain2 string
+
+	// Name of the relayer relaying the link.
+	Relayer string
+
+	// Name of the relayer's path.
+	Path string
+
+	// If set, the name of the relayer whose path of the same name this link shares.
+	ShareWith string
+
+	// Optional options of the channels created on the path, in order.
+	// By default, a single ICS-20 transfer channel is created.
+	Channels []InterchainChannelSpec
+}
+
+// InterchainChannelSpec is the form of ibc.CreateChannelOptions in an InterchainSpec.
+type InterchainChannelSpec struct {
+	SourcePort, DestPort string
+
+	// Order is "ordered" or "unordered".
+	Order string
+
+	Version string
+}
+
+// InterchainUserSpec is a user of an InterchainSpec.
+type InterchainUserSpec struct {
+	// Name of the user, used as the key name prefix and in InterchainHandles.Users.
+	Name string
+
+	// Handle of the chain on which the user is created.
+	Chain string
+
+	// Amount of the chain's native denom sent to the user from the faucet.
+	Amount int64
+
+	// If set, the user's key is recovered from the mnemonic instead of being generated.
+	Mnemonic string
+}
+
+// ReadInterchainSpec reads and validates an InterchainSpec from the YAML or JSON file at path.
+func ReadInterchainSpec(path string) (*InterchainSpec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := ParseInterchainSpec(b)
+	if err != nil {
+		return nil, fmt.Errorf("interchain spec %s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// ParseInterchainSpec parses and validates an InterchainSpec from YAML or JSON.
+// Unknown fields are rejected, to catch misspelled field names.
+func ParseInterchainSpec(b []byte) (*InterchainSpec, error) {
+	// JSON is valid YAML, and ChainSpec embeds ibc.ChainConfig, which only the json package inlines,
+	// so decode the YAML to generic values and then decode those as JSON.
+	var raw any
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	j, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("convert to json: %w", err)
+	}
+
+	var spec InterchainSpec
+	dec := json.NewDecoder(strings.NewReader(string(j)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate returns an error if the spec is incomplete, refers to undeclared chains or relayers,
+// or declares links that Interchain.AddLink would reject.
+func (s *InterchainSpec) Validate() error {
+	if len(s.Chains) == 0 {
+		return errors.New("no chains")
+	}
+
+	chains := make(map[string]bool, len(s.Chains))
+	for i := range s.Chains {
+		c := &s.Chains[i]
+		if c.ChainSpec == nil {
+			return fmt.Errorf("chain at index %d has no chain spec", i)
+		}
+		cfg, err := c.Config()
+		if err != nil {
+			return fmt.Errorf("chain at index %d: %w", i, err)
+		}
+		if c.Handle == "" {
+			c.Handle = cfg.ChainID
+		}
+		if chains[c.Handle] {
+			return fmt.Errorf("duplicate chain handle %q", c.Handle)
+		}
+		chains[c.Handle] = true
+	}
+
+	relayers := make(map[string]bool, len(s.Relayers))
+	for i, r := range s.Relayers {
+		if r.Name == "" {
+			return fmt.Errorf("relayer at index %d has no name", i)
+		}
+		if relayers[r.Name] {
+			return fmt.Errorf("duplicate relayer name %q", r.Name)
+		}
+		if _, err := relayerImplementation(r.Type); err != nil {
+			return fmt.Errorf("relayer %s: %w", r.Name, err)
+		}
+		relayers[r.Name] = true
+	}
+
+	// Links by relayer and path name, to check paths are unique and shared paths, as AddLink does.
+	type relayerPathName struct{ relayer, path string }
+	links := make(map[relayerPathName]InterchainLinkSpec, len(s.Links))
+	for i, l := range s.Links {
+		if !chains[l.Chain1] || !chains[l.Chain2] {
+			return fmt.Errorf("link at index %d: unknown chain %q or %q", i, l.Chain1, l.Chain2)
+		}
+		if l.Chain1 == l.Chain2 {
+			return fmt.Errorf("link at index %d: chains must be different (both were %q)", i, l.Chain1)
+		}
+		if !relayers[l.Relayer] {
+			return fmt.Errorf("link at index %d: unknown relayer %q", i, l.Relayer)
+		}
+		if l.Path == "" {
+			return fmt.Errorf("link at index %d has no path", i)
+		}
+		if l.ShareWith != "" && !relayers[l.ShareWith] {
+			return fmt.Errorf("link at index %d: unknown relayer %q to share with", i, l.ShareWith)
+		}
+		key := relayerPathName{relayer: l.Relayer, path: l.Path}
+		if _, exists := links[key]; exists {
+			return fmt.Errorf("link at index %d: relayer %q already has a path named %q", i, l.Relayer, l.Path)
+		}
+		if l.ShareWith != "" {
+			owner, exists := links[relayerPathName{relayer: l.ShareWith, path: l.Path}]
+			if !exists {
+				return fmt.Errorf("link at index %d: relayer %q has no path named %q declared before it to share", i, l.ShareWith, l.Path)
+			}
+			sameChains := (owner.Chain1 == l.Chain1 && owner.Chain2 == l.Chain2) || (owner.Chain1 == l.Chain2 && owner.Chain2 == l.Chain1)
+			if !sameChains {
+				return fmt.Errorf("link at index %d: path %q of relayer %q does not link the same chains", i, l.Path, l.ShareWith)
+			}
+			if owner.ShareWith != "" {
+				return fmt.Errorf("link at index %d: path %q of relayer %q is itself shared", i, l.Path, l.ShareWith)
+			}
+			if len(l.Channels) > 0 {
+				return fmt.Errorf("link at index %d: cannot set channels on a path shared with relayer %q", i, l.ShareWith)
+			}
+		}
+		links[key] = l
+		for j, ch := range l.Channels {
+			opts, err := ch.CreateChannelOptions()
+			if err != nil {
+				return fmt.Errorf("link at index %d, channel %d: %w", i, j, err)
+			}
+			if err := opts.Validate(); err != nil {
+				return fmt.Errorf("link at index %d: invalid channel %d: %w", i, j, err)
+			}
+		}
+	}
+
+	for i, u := range s.Users {
+		if u.Name == "" {
+			return fmt.Errorf("user at index %d has no name", i)
+		}
+		if !chains[u.Chain] {
+			return fmt.Errorf("user %s: unknown chain %q", u.Name, u.Chain)
+		}
+		if u.Amount <= 0 {
+			return fmt.Errorf("user %s: amount must be positive", u.Name)
+		}
+	}
+
+	return nil
+}
+
+// CreateChannelOptions returns the ibc.CreateChannelOptions described by c.
+func (c InterchainChannelSpec) CreateChannelOptions() (ibc.CreateChannelOptions, error) {
+	opts := ibc.CreateChannelOptions{
+		SourcePortName: c.SourcePort,
+		DestPortName:   c.DestPort,
+		Version:        c.Version,
+	}
+	switch strings.ToLower(c.Order) {
+	case ibc.Ordered.String():
+		opts.Order = ibc.Ordered
+	case ibc.Unordered.String():
+		opts.Order = ibc.Unordered
+	default:
+		return ibc.CreateChannelOptions{}, fmt.Errorf("unknown channel order %q (valid orders: ordered, unordered)", c.Order)
+	}
+	return opts, nil
+}
+
+// relayerImplementation returns the implementation for the relayer type of an InterchainRelayerSpec.
+func relayerImplementation(typ string) (ibc.RelayerImplementation, error) {
+	switch typ {
+	case "rly", "cosmos/relayer":
+		return ibc.CosmosRly, nil
+	case "hermes":
+		return ibc.Hermes, nil
+	default:
+		return 0, fmt.Errorf("unknown relayer type %q (valid types: rly, hermes)", typ)
+	}
+}
+
+// RelayerFactory returns the factory of the relayer described by r.
+func (r InterchainRelayerSpec) RelayerFactory(log *zap.Logger) (RelayerFactory, error) {
+	impl, err := relayerImplementation(r.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	var options []relayer.RelayerOption
+	if r.Image != nil {
+		options = append(options, relayer.CustomDockerImage(r.Image.Repository, r.Image.Version))
+	}
+	if r.Pull != nil {
+		options = append(options, relayer.ImagePull(*r.Pull))
+	}
+	if len(r.StartFlags) > 0 {
+		options = append(options, relayer.StartupFlags(r.StartFlags...))
+	}
+	if r.Metrics {
+		options = append(options, relayer.EnableMetrics())
+	}
+	return NewBuiltinRelayerFactory(impl, log, options...), nil
+}
+
+// InterchainHandles is a built Interchain created from an InterchainSpec,
+// with its chains, relayers and users keyed by their handles and names in the spec.
+type InterchainHandles struct {
+	Interchain *Interchain
+
+	// Chains keyed by handle.
+	Chains map[string]ibc.Chain
+
+	// Relayers, and the factories that built them, keyed by name.
+	Relayers         map[string]ibc.Relayer
+	RelayerFactories map[string]RelayerFactory
+
+	// Users of the spec, funded on their chains, keyed by name.
+	Users map[string]*User
+}
+
+// Interchain creates the chains and relayers of the spec, builds an Interchain of them and the spec's links
+// with opts, whose Client and NetworkID are also used for the relayers,
+// and creates the spec's users, each funded from the faucet of its chain.
+// The funds are accessible once Interchain returns.
+// The Interchain is closed when t's cleanup runs.
+//
+// The spec is validated first, so that a spec constructed in Go rather than parsed is also checked.
+func (s *InterchainSpec) Interchain(t *testing.T, ctx context.Context, rep *testreporter.RelayerExecReporter, log *zap.Logger, opts InterchainBuildOptions) (*InterchainHandles, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	specs := make([]*ChainSpec, len(s.Chains))
+	for i, c := range s.Chains {
+		specs[i] = c.ChainSpec
+	}
+	chains, err := NewBuiltinChainFactory(log, specs).Chains(t.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	h := &InterchainHandles{
+		Interchain: NewInterchain().WithLog(log),
+
+		Chains: make(map[string]ibc.Chain, len(chains)),
+
+		Relayers:         make(map[string]ibc.Relayer, len(s.Relayers)),
+		RelayerFactories: make(map[string]RelayerFactory, len(s.Relayers)),
+
+		Users: make(map[string]*User, len(s.Users)),
+	}
+	for i, c := range chains {
+		h.Chains[s.Chains[i].Handle] = c
+		h.Interchain.AddChain(c)
+	}
+
+	for _, rs := range s.Relayers {
+		rf, err := rs.RelayerFactory(log)
+		if err != nil {
+			return nil, fmt.Errorf("relayer %s: %w", rs.Name, err)
+		}
+		r := rf.Build(t, opts.Client, opts.NetworkID)
+		h.Relayers[rs.Name] = r
+		h.RelayerFactories[rs.Name] = rf
+		h.Interchain.AddRelayer(r, rs.Name)
+	}
+
+	for _, ls := range s.Links {
+		link := InterchainLink{
+			Chain1:  h.Chains[ls.Chain1],
+			Chain2:  h.Chains[ls.Chain2],
+			Relayer: h.Relayers[ls.Relayer],
+
+			Path: ls.Path,
+		}
+		if ls.ShareWith != "" {
+			link.ShareWith = h.Relayers[ls.ShareWith]
+		}
+		for _, ch := range ls.Channels {
+			// Already validated.
+			chOpts, _ := ch.CreateChannelOptions()
+			link.CreateChannelOpts = append(link.CreateChannelOpts, chOpts)
+		}
+		h.Interchain.AddLink(link)
+	}
+
+	err = h.Interchain.Build(ctx, rep, opts)
+	// Close a partially built Interchain too.
+	t.Cleanup(func() {
+		_ = h.Interchain.Close()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("build interchain: %w", err)
+	}
+
+	var fundedChains []test.ChainHeighter
+	for _, u := range s.Users {
+		c := h.Chains[u.Chain]
+		h.Users[u.Name] = GetAndFundTestUserWithMnemonic(t, ctx, u.Name, u.Mnemonic, u.Amount, c)
+		fundedChains = append(fundedChains, c)
+	}
+	if len(fundedChains) > 0 {
+		// Wait for the funds to be accessible.
+		if err := test.WaitForBlocks(ctx, 2, fundedChains...); err != nil {
+			return nil, fmt.Errorf("wait for user funds: %w", err)
+		}
+	}
+
+	return h, nil
+}
@@ -0,0 +1,216 @@
+package ibctest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const interchainSpecYAML = `
+chains:
+  - handle: hub
+    name: gaia
+    version: v7.0.1
+  - name: osmosis
+    version: v7.2.0
+    chainID: osmo-1
+    numValidators: 1
+
+relayers:
+  - name: r
+    type: rly
+    startFlags: ["--processor", "events"]
+  - name: h
+    type: hermes
+    image:
+      repository: ghcr.io/informalsystems/hermes
+      version: v1.0.0
+
+links:
+  - chain1: hub
+    chain2: osmo-1
+    relayer: r
+    path: transfer
+  - chain1: osmo-1
+    chain2: hub
+    relayer: h
+    path: transfer
+    shareWith: r
+  - chain1: hub
+    chain2: osmo-1
+    relayer: h
+    path: multi
+    channels:
+      - sourcePort: transfer
+        destPort: transfer
+        order: unordered
+        version: ics20-1
+      - sourcePort: icacontroller-owner
+        destPort: icahost
+        order: ordered
+        version: ics27-1
+
+users:
+  - name: alice
+    chain: hub
+    amount: 1000000
+`
+
+func TestParseInterchainSpec(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		spec, err := ibctest.ParseInterchainSpec([]byte(interchainSpecYAML))
+		require.NoError(t, err)
+
+		require.Len(t, spec.Chains, 2)
+		require.Equal(t, "hub", spec.Chains[0].Handle)
+		require.Equal(t, "gaia", spec.Chains[0].Name)
+		// The handle defaults to the chain ID.
+		require.Equal(t, "osmo-1", spec.Chains[1].Handle)
+		require.Equal(t, 1, *spec.Chains[1].NumValidators)
+
+		require.Len(t, spec.Relayers, 2)
+		require.Equal(t, []string{"--processor", "events"}, spec.Relayers[0].StartFlags)
+		require.Equal(t, "v1.0.0", spec.Relayers[1].Image.Version)
+
+		require.Len(t, spec.Links, 3)
+		require.Equal(t, "r", spec.Links[1].ShareWith)
+		require.Len(t, spec.Links[2].Channels, 2)
+		opts, err := spec.Links[2].Channels[0].CreateChannelOptions()
+		require.NoError(t, err)
+		require.Equal(t, ibc.DefaultChannelOpts(), opts)
+		opts, err = spec.Links[2].Channels[1].CreateChannelOptions()
+		require.NoError(t, err)
+		require.Equal(t, ibc.CreateChannelOptions{
+			SourcePortName: "icacontroller-owner",
+			DestPortName:   "icahost",
+			Order:          ibc.Ordered,
+			Version:        "ics27-1",
+		}, opts)
+
+		require.Equal(t, []ibctest.InterchainUserSpec{{Name: "alice", Chain: "hub", Amount: 1000000}}, spec.Users)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		spec, err := ibctest.ParseInterchainSpec([]byte(`{
+  "Chains": [
+    {"Handle": "a", "Name": "gaia", "Version": "v7.0.1"},
+    {"Handle": "b", "Name": "juno", "Version": "v9.0.0"}
+  ],
+  "Relayers": [{"Name": "r", "Type": "rly"}],
+  "Links": [{"Chain1": "a", "Chain2": "b", "Relayer": "r", "Path": "p"}]
+}`))
+		require.NoError(t, err)
+		require.Len(t, spec.Chains, 2)
+		require.Len(t, spec.Links, 1)
+		require.Empty(t, spec.Links[0].Channels)
+	})
+
+	for _, tc := range []struct {
+		name, spec, wantErr string
+	}{
+		{
+			name:    "unknown field",
+			spec:    "chains: [{name: gaia, version: v7.0.1, validators: 3}]",
+			wantErr: "unknown field",
+		},
+		{
+			name:    "no chains",
+			spec:    "relayers: [{name: r, type: rly}]",
+			wantErr: "no chains",
+		},
+		{
+			name:    "duplicate handle",
+			spec:    "chains: [{handle: a, name: gaia, version: v7.0.1}, {handle: a, name: juno, version: v9.0.0}]",
+			wantErr: `duplicate chain handle "a"`,
+		},
+		{
+			name:    "unknown relayer type",
+			spec:    "chains: [{name: gaia, version: v7.0.1}]\nrelayers: [{name: r, type: go-relayer}]",
+			wantErr: `unknown relayer type "go-relayer"`,
+		},
+		{
+			name: "unknown link chain",
+			spec: `chains: [{handle: a, name: gaia, version: v7.0.1}, {handle: b, name: juno, version: v9.0.0}]
+relayers: [{name: r, type: rly}]
+links: [{chain1: a, chain2: c, relayer: r, path: p}]`,
+			wantErr: `unknown chain "a" or "c"`,
+		},
+		{
+			name: "invalid channel order",
+			spec: `chains: [{handle: a, name: gaia, version: v7.0.1}, {handle: b, name: juno, version: v9.0.0}]
+relayers: [{name: r, type: rly}]
+links: [{chain1: a, chain2: b, relayer: r, path: p, channels: [{sourcePort: transfer, destPort: transfer, order: sorted, version: ics20-1}]}]`,
+			wantErr: `unknown channel order "sorted"`,
+		},
+		{
+			name: "channels on shared path",
+			spec: `chains: [{handle: a, name: gaia, version: v7.0.1}, {handle: b, name: juno, version: v9.0.0}]
+relayers: [{name: r, type: rly}, {name: h, type: hermes}]
+links:
+  - {chain1: a, chain2: b, relayer: r, path: p}
+  - {chain1: a, chain2: b, relayer: h, path: p, shareWith: r, channels: [{sourcePort: transfer, destPort: transfer, order: unordered, version: ics20-1}]}`,
+			wantErr: `cannot set channels on a path shared with relayer "r"`,
+		},
+		{
+			name: "duplicate path",
+			spec: `chains: [{handle: a, name: gaia, version: v7.0.1}, {handle: b, name: juno, version: v9.0.0}]
+relayers: [{name: r, type: rly}]
+links:
+  - {chain1: a, chain2: b, relayer: r, path: p}
+  - {chain1: b, chain2: a, relayer: r, path: p}`,
+			wantErr: `link at index 1: relayer "r" already has a path named "p"`,
+		},
+		{
+			name: "shared path declared before owner",
+			spec: `chains: [{handle: a, name: gaia, version: v7.0.1}, {handle: b, name: juno, version: v9.0.0}]
+relayers: [{name: r, type: rly}, {name: h, type: hermes}]
+links:
+  - {chain1: a, chain2: b, relayer: h, path: p, shareWith: r}
+  - {chain1: a, chain2: b, relayer: r, path: p}`,
+			wantErr: `link at index 0: relayer "r" has no path named "p" declared before it to share`,
+		},
+		{
+			name: "shared path links other chains",
+			spec: `chains: [{handle: a, name: gaia, version: v7.0.1}, {handle: b, name: juno, version: v9.0.0}, {handle: c, name: osmosis, version: v7.2.0}]
+relayers: [{name: r, type: rly}, {name: h, type: hermes}]
+links:
+  - {chain1: a, chain2: b, relayer: r, path: p}
+  - {chain1: a, chain2: c, relayer: h, path: p, shareWith: r}`,
+			wantErr: `link at index 1: path "p" of relayer "r" does not link the same chains`,
+		},
+		{
+			name: "shared path itself shared",
+			spec: `chains: [{handle: a, name: gaia, version: v7.0.1}, {handle: b, name: juno, version: v9.0.0}]
+relayers: [{name: r, type: rly}, {name: h, type: hermes}, {name: h2, type: hermes}]
+links:
+  - {chain1: a, chain2: b, relayer: r, path: p}
+  - {chain1: a, chain2: b, relayer: h, path: p, shareWith: r}
+  - {chain1: a, chain2: b, relayer: h2, path: p, shareWith: h}`,
+			wantErr: `link at index 2: path "p" of relayer "h" is itself shared`,
+		},
+		{
+			name:    "unknown user chain",
+			spec:    "chains: [{handle: a, name: gaia, version: v7.0.1}]\nusers: [{name: u, chain: b, amount: 1}]",
+			wantErr: `user u: unknown chain "b"`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ibctest.ParseInterchainSpec([]byte(tc.spec))
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestInterchainSpec_Interchain_Invalid(t *testing.T) {
+	// A spec constructed in Go is validated before anything is created or built.
+	spec := &ibctest.InterchainSpec{
+		Relayers: []ibctest.InterchainRelayerSpec{{Name: "r", Type: "rly"}},
+	}
+	_, err := spec.Interchain(t, context.Background(), nil, zap.NewNop(), ibctest.InterchainBuildOptions{})
+	require.ErrorContains(t, err, "no chains")
+}
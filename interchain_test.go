@@ -151,9 +151,95 @@ func TestInterchain_GetRelayerWallets(t *testing.T) {
 		require.False(t, ok)
 	})
 
+	t.Run("Interchain reports the relayer wallets", func(t *testing.T) {
+		w, ok := ic.RelayerWallet(r, gaia0)
+		require.True(t, ok)
+		require.Equal(t, g1Wallet, w)
+
+		link := ic.Link(r, "")
+		require.Equal(t, g1Wallet, link.Wallet1)
+		require.Equal(t, g2Wallet, link.Wallet2)
+
+		// Paths were not created, so the link has no identifiers.
+		require.Empty(t, link.End1.ConnectionID)
+		require.Empty(t, link.Channels)
+	})
+
 	_ = ic.Close()
 }
 
+func TestInterchain_MultipleChannelsPerLink(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	cf := ibctest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*ibctest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	gaia0, gaia1 := chains[0], chains[1]
+
+	r := ibctest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(
+		t, client, network,
+	)
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(gaia0).
+		AddChain(gaia1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  gaia0,
+			Chain2:  gaia1,
+			Relayer: r,
+			Path:    pathName,
+
+			CreateChannelOpts: []ibc.CreateChannelOptions{ibc.DefaultChannelOpts(), ibc.DefaultChannelOpts()},
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		HomeDir:   home,
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	link := ic.Link(r, pathName)
+	require.Len(t, link.Channels, 2)
+	require.NotEqual(t, link.Channels[0].ChannelID, link.Channels[1].ChannelID)
+	for _, ch := range link.Channels {
+		// Both channels are on the connection created for the link.
+		require.Equal(t, []string{link.End1.ConnectionID}, ch.ConnectionHops)
+		require.Equal(t, "transfer", ch.PortID)
+	}
+
+	// Both channels are open on each chain.
+	for _, c := range chains {
+		channels, err := r.GetChannels(ctx, eRep, c.Config().ChainID)
+		require.NoError(t, err)
+		require.Len(t, channels, 2)
+		for _, ch := range channels {
+			require.Equal(t, "STATE_OPEN", ch.State)
+		}
+	}
+}
+
 func TestInterchain_CreateUser(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping in short mode")
@@ -275,19 +361,24 @@ func TestCosmosChain_BroadcastTx(t *testing.T) {
 		NetworkID: network,
 	}))
 
+	link := ic.Link(r, pathName)
+	require.Len(t, link.Channels, 1)
+	channel := link.Channels[0]
+	require.Equal(t, link.End1.ConnectionID, channel.ConnectionHops[0])
+
 	testUser := ibctest.GetAndFundTestUsers(t, ctx, "gaia-user-1", 10_000_000, gaia0)[0]
 
 	sendAmount := int64(10000)
 
 	t.Run("relayer starts", func(t *testing.T) {
-		require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+		require.NoError(t, ic.StartRelayers(t, ctx, eRep))
 	})
 
 	t.Run("broadcast success", func(t *testing.T) {
 		b := cosmos.NewBroadcaster(t, gaia0.(*cosmos.CosmosChain))
 		transferAmount := types.Coin{Denom: gaia0.Config().Denom, Amount: types.NewInt(sendAmount)}
 
-		msg := transfertypes.NewMsgTransfer("transfer", "channel-0", transferAmount, testUser.Bech32Address(gaia0.Config().Bech32Prefix), testUser.Bech32Address(gaia1.Config().Bech32Prefix), clienttypes.NewHeight(1, 1000), 0)
+		msg := transfertypes.NewMsgTransfer(channel.PortID, channel.ChannelID, transferAmount, testUser.Bech32Address(gaia0.Config().Bech32Prefix), testUser.Bech32Address(gaia1.Config().Bech32Prefix), clienttypes.NewHeight(1, 1000), 0)
 		resp, err := cosmos.BroadcastTx(ctx, b, testUser, msg)
 		require.NoError(t, err)
 		assertTransactionIsValid(t, resp)
@@ -296,7 +387,7 @@ func TestCosmosChain_BroadcastTx(t *testing.T) {
 	t.Run("transfer success", func(t *testing.T) {
 		require.NoError(t, test.WaitForBlocks(ctx, 5, gaia0, gaia1))
 
-		srcDenomTrace := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom("transfer", "channel-0", gaia0.Config().Denom))
+		srcDenomTrace := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(channel.Counterparty.PortID, channel.Counterparty.ChannelID, gaia0.Config().Denom))
 		dstIbcDenom := srcDenomTrace.IBCDenom()
 
 		dstFinalBalance, err := gaia1.GetBalance(ctx, testUser.Bech32Address(gaia1.Config().Bech32Prefix), dstIbcDenom)
@@ -305,6 +396,128 @@ func TestCosmosChain_BroadcastTx(t *testing.T) {
 	})
 }
 
+func TestInterchain_Snapshot(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := ibctest.DockerSetup(t)
+	snapshotDir := ibctest.TempDir(t)
+
+	const pathName = "p"
+
+	ctx := context.Background()
+	rep := testreporter.NewNopReporter()
+
+	// build builds two linked chains with the snapshot directory of the test.
+	build := func(t *testing.T) (*ibctest.Interchain, ibc.Chain, ibc.Chain, ibc.Relayer) {
+		cf := ibctest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*ibctest.ChainSpec{
+			{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+			{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+		})
+		chains, err := cf.Chains(t.Name())
+		require.NoError(t, err)
+		gaia0, gaia1 := chains[0], chains[1]
+
+		r := ibctest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(
+			t, client, network,
+		)
+
+		ic := ibctest.NewInterchain().
+			AddChain(gaia0).
+			AddChain(gaia1).
+			AddRelayer(r, "r").
+			AddLink(ibctest.InterchainLink{
+				Chain1:  gaia0,
+				Chain2:  gaia1,
+				Relayer: r,
+				Path:    pathName,
+			})
+
+		require.NoError(t, ic.Build(ctx, rep.RelayerExecReporter(t), ibctest.InterchainBuildOptions{
+			TestName:  t.Name(),
+			HomeDir:   ibctest.TempDir(t),
+			Client:    client,
+			NetworkID: network,
+
+			// Blocks are tracked once the snapshot is saved, since saving it restarts the chains.
+			BlockDatabaseFile: ":memory:",
+
+			SnapshotDir: snapshotDir,
+		}))
+		t.Cleanup(func() {
+			_ = ic.Close()
+		})
+		return ic, gaia0, gaia1, r
+	}
+
+	var saved ibctest.BuiltLink
+	var savedHeight uint64
+	t.Run("save", func(t *testing.T) {
+		ic, gaia0, _, r := build(t)
+		saved = ic.Link(r, pathName)
+
+		var err error
+		savedHeight, err = gaia0.Height(ctx)
+		require.NoError(t, err)
+	})
+	if t.Failed() {
+		return
+	}
+
+	t.Run("restore", func(t *testing.T) {
+		ic, gaia0, gaia1, r := build(t)
+
+		// The chains continue from the snapshot rather than from genesis.
+		height, err := gaia0.Height(ctx)
+		require.NoError(t, err)
+		require.Greater(t, height, savedHeight)
+
+		restored := ic.Link(r, pathName)
+		require.Equal(t, saved.End1, restored.End1)
+		require.Equal(t, saved.End2, restored.End2)
+		require.Equal(t, saved.Channels, restored.Channels)
+		require.Equal(t, saved.Wallet1, restored.Wallet1)
+		require.Equal(t, saved.Wallet2, restored.Wallet2)
+
+		eRep := rep.RelayerExecReporter(t)
+		require.NoError(t, ic.StartRelayers(t, ctx, eRep))
+
+		// The faucet key was restored with the node volumes.
+		user := ibctest.GetAndFundTestUsers(t, ctx, "snapshot", 10_000_000, gaia0)[0]
+		channel := restored.Channels[0]
+		tx, err := gaia0.SendIBCTransfer(ctx, channel.ChannelID, user.KeyName, ibc.WalletAmount{
+			Address: user.Bech32Address(gaia1.Config().Bech32Prefix),
+			Denom:   gaia0.Config().Denom,
+			Amount:  10000,
+		}, nil)
+		require.NoError(t, err)
+		require.NoError(t, tx.Validate())
+
+		_, err = test.PollForAck(ctx, gaia0, tx.Height, tx.Height+10, tx.Packet)
+		require.NoError(t, err, "no acknowledgement of transfer on restored channel")
+	})
+}
+
+func TestInterchain_Snapshot_ModifyGenesisWithoutKey(t *testing.T) {
+	cfg := ibc.ChainConfig{
+		ChainID: "cosmoshub-0",
+		ModifyGenesis: func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+			return genbz, nil
+		},
+	}
+	c := cosmos.NewCosmosChain(t.Name(), cfg, 1, 0, zap.NewNop())
+
+	ic := ibctest.NewInterchain().AddChain(c)
+	err := ic.Build(context.Background(), testreporter.NewNopReporter().RelayerExecReporter(t), ibctest.InterchainBuildOptions{
+		TestName:    t.Name(),
+		SnapshotDir: ibctest.TempDir(t),
+	})
+	require.ErrorContains(t, err, "without a SnapshotKey")
+}
+
 // An external package that imports ibctest may not provide a GitSha when they provide a BlockDatabaseFile.
 // The GitSha field is documented as optional, so this should succeed.
 func TestInterchain_OmitGitSHA(t *testing.T) {
@@ -406,6 +619,111 @@ func TestInterchain_ConflictRejection(t *testing.T) {
 			_ = ibctest.NewInterchain().AddRelayer(&r1, "r").AddRelayer(&r2, "r")
 		})
 	})
+
+	t.Run("shared path", func(t *testing.T) {
+		cf := ibctest.NewBuiltinChainFactory(zap.NewNop(), []*ibctest.ChainSpec{
+			{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+			{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+			{Name: "gaia", ChainName: "g3", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-2"}},
+		})
+
+		chains, err := cf.Chains(t.Name())
+		require.NoError(t, err)
+		g1, g2, g3 := chains[0], chains[1], chains[2]
+
+		var r1, r2, r3 rly.CosmosRelayer
+		newInterchain := func() *ibctest.Interchain {
+			return ibctest.NewInterchain().
+				AddChain(g1).
+				AddChain(g2).
+				AddChain(g3).
+				AddRelayer(&r1, "r1").
+				AddRelayer(&r2, "r2").
+				AddRelayer(&r3, "r3").
+				AddLink(ibctest.InterchainLink{Chain1: g1, Chain2: g2, Relayer: &r1, Path: "p"})
+		}
+
+		// Sharing with the chains in either order is allowed.
+		require.NotPanics(t, func() {
+			_ = newInterchain().
+				AddLink(ibctest.InterchainLink{Chain1: g2, Chain2: g1, Relayer: &r2, Path: "p", ShareWith: &r1}).
+				AddLink(ibctest.InterchainLink{Chain1: g1, Chain2: g2, Relayer: &r3, Path: "p", ShareWith: &r1})
+		})
+
+		require.PanicsWithError(t, `relayer r2 cannot share path "q" of relayer r1, which does not link the same chains`, func() {
+			_ = newInterchain().AddLink(ibctest.InterchainLink{Chain1: g1, Chain2: g2, Relayer: &r2, Path: "q", ShareWith: &r1})
+		})
+		require.PanicsWithError(t, `relayer r2 cannot share path "p" of relayer r1, which does not link the same chains`, func() {
+			_ = newInterchain().AddLink(ibctest.InterchainLink{Chain1: g1, Chain2: g3, Relayer: &r2, Path: "p", ShareWith: &r1})
+		})
+		require.PanicsWithError(t, `relayer r3 cannot share path "p" of relayer r2, which is itself shared`, func() {
+			_ = newInterchain().
+				AddLink(ibctest.InterchainLink{Chain1: g1, Chain2: g2, Relayer: &r2, Path: "p", ShareWith: &r1}).
+				AddLink(ibctest.InterchainLink{Chain1: g1, Chain2: g2, Relayer: &r3, Path: "p", ShareWith: &r2})
+		})
+		require.PanicsWithError(t, `relayer r2 cannot set channel options on path "p" shared with relayer r1`, func() {
+			_ = newInterchain().AddLink(ibctest.InterchainLink{
+				Chain1: g1, Chain2: g2, Relayer: &r2, Path: "p", ShareWith: &r1,
+				CreateChannelOpts: []ibc.CreateChannelOptions{ibc.DefaultChannelOpts()},
+			})
+		})
+	})
+
+	t.Run("invalid channel options", func(t *testing.T) {
+		cf := ibctest.NewBuiltinChainFactory(zap.NewNop(), []*ibctest.ChainSpec{
+			{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+			{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+		})
+
+		chains, err := cf.Chains(t.Name())
+		require.NoError(t, err)
+		g1, g2 := chains[0], chains[1]
+
+		var r rly.CosmosRelayer
+		ica := ibc.CreateChannelOptions{
+			SourcePortName: "icacontroller-cosmos1owner",
+			DestPortName:   "icahost",
+			Order:          ibc.Ordered,
+			Version:        "ics27-1",
+		}
+
+		// Channels of different orders may be created on one path.
+		require.NotPanics(t, func() {
+			_ = ibctest.NewInterchain().
+				AddChain(g1).
+				AddChain(g2).
+				AddRelayer(&r, "r").
+				AddLink(ibctest.InterchainLink{
+					Chain1: g1, Chain2: g2, Relayer: &r, Path: "p",
+					CreateChannelOpts: []ibc.CreateChannelOptions{ibc.DefaultChannelOpts(), ica},
+				})
+		})
+
+		// The transfer module only accepts unordered channels.
+		orderedTransfer := ibc.DefaultChannelOpts()
+		orderedTransfer.Order = ibc.Ordered
+		require.PanicsWithError(t, `invalid options of channel 1 for path "p" of relayer r: transfer channels must be unordered: invalid channel ordering`, func() {
+			_ = ibctest.NewInterchain().
+				AddChain(g1).
+				AddChain(g2).
+				AddRelayer(&r, "r").
+				AddLink(ibctest.InterchainLink{
+					Chain1: g1, Chain2: g2, Relayer: &r, Path: "p",
+					CreateChannelOpts: []ibc.CreateChannelOptions{ibc.DefaultChannelOpts(), orderedTransfer},
+				})
+		})
+
+		require.PanicsWithError(t, `invalid options of channel 1 for path "p" of relayer r: invalid channel ordering`, func() {
+			_ = ibctest.NewInterchain().
+				AddChain(g1).
+				AddChain(g2).
+				AddRelayer(&r, "r").
+				AddLink(ibctest.InterchainLink{
+					Chain1: g1, Chain2: g2, Relayer: &r, Path: "p",
+					CreateChannelOpts: []ibc.CreateChannelOptions{ibc.DefaultChannelOpts(), {SourcePortName: "transfer", DestPortName: "transfer", Version: "ics20-1"}},
+				})
+		})
+	})
 }
 
 func TestInterchain_AddNil(t *testing.T) {
@@ -146,6 +146,20 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 		return fmt.Errorf("create table tendermint_event: %w", err)
 	}
 
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS relayer_log (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    container_name TEXT NOT NULL,
+    logged_at TEXT NOT NULL CHECK (length(logged_at) > 0),
+    level TEXT NOT NULL,
+    message TEXT NOT NULL,
+    fields TEXT NOT NULL, -- JSON object
+    fk_test_id INTEGER,
+    FOREIGN KEY(fk_test_id) REFERENCES test_case(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table relayer_log: %w", err)
+	}
+
 	// Creating views should be last migration step.
 	if err := upsertViews(tx); err != nil {
 		// Error already wrapped.
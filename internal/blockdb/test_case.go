@@ -3,6 +3,8 @@ package blockdb
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
 )
 
 // TestCase is a single test invocation.
@@ -44,3 +46,18 @@ func (tc *TestCase) AddChain(ctx context.Context, chainID, chainType string) (*C
 		db: tc.db,
 	}, nil
 }
+
+// SaveRelayerLog tracks a line logged by a long-running relayer during the test case.
+// The level is empty if the relayer's log format was not recognized.
+func (tc *TestCase) SaveRelayerLog(ctx context.Context, containerName string, when time.Time, level, message string, fields map[string]string) error {
+	if fields == nil {
+		fields = map[string]string{}
+	}
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	_, err = tc.db.ExecContext(ctx, `INSERT INTO relayer_log(container_name, logged_at, level, message, fields, fk_test_id) VALUES(?, ?, ?, ?, ?, ?)`,
+		containerName, when.UTC().Format(time.RFC3339Nano), level, message, string(fieldsJSON), tc.id)
+	return err
+}
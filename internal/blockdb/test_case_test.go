@@ -93,3 +93,40 @@ func TestTestCase_AddChain(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestTestCase_SaveRelayerLog(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "SomeTest", "abc")
+	require.NoError(t, err)
+
+	loggedAt := time.Date(2022, 8, 1, 12, 0, 0, 123000000, time.UTC)
+	require.NoError(t, tc.SaveRelayerLog(ctx, "rly-p", loggedAt, "info", "Successful transaction", map[string]string{"chain_id": "gaia-1"}))
+	require.NoError(t, tc.SaveRelayerLog(ctx, "rly-p", loggedAt, "", "unparsed line", nil))
+
+	rows, err := db.Query(`SELECT container_name, logged_at, level, message, fields, fk_test_id FROM relayer_log ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	type logRow struct {
+		Container, LoggedAt, Level, Message, Fields string
+		TestID                                      int
+	}
+	var got []logRow
+	for rows.Next() {
+		var r logRow
+		require.NoError(t, rows.Scan(&r.Container, &r.LoggedAt, &r.Level, &r.Message, &r.Fields, &r.TestID))
+		got = append(got, r)
+	}
+	require.NoError(t, rows.Err())
+
+	require.Equal(t, []logRow{
+		{Container: "rly-p", LoggedAt: "2022-08-01T12:00:00.123Z", Level: "info", Message: "Successful transaction", Fields: `{"chain_id":"gaia-1"}`, TestID: 1},
+		{Container: "rly-p", LoggedAt: "2022-08-01T12:00:00.123Z", Message: "unparsed line", Fields: `{}`, TestID: 1},
+	}, got)
+}
@@ -0,0 +1,170 @@
+package dockerutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"go.uber.org/zap"
+)
+
+// netemImageRef is the image providing the tc command,
+// run in the network namespace of a container to shape its traffic.
+const netemImageRef = "nicolaka/netshoot:v0.7"
+
+// DisconnectNetwork disconnects the container from the network,
+// so that it can neither reach nor be reached by other containers on the network.
+func DisconnectNetwork(ctx context.Context, cli *client.Client, networkID, containerID string) error {
+	if err := cli.NetworkDisconnect(ctx, networkID, containerID, true); err != nil {
+		return fmt.Errorf("disconnecting container %s from network: %w", containerID, err)
+	}
+	return nil
+}
+
+// ConnectNetwork reconnects a container disconnected by DisconnectNetwork.
+// The container keeps its name on the network, but it may be assigned a different IP address.
+func ConnectNetwork(ctx context.Context, cli *client.Client, networkID, containerID string) error {
+	if err := cli.NetworkConnect(ctx, networkID, containerID, &network.EndpointSettings{}); err != nil {
+		return fmt.Errorf("connecting container %s to network: %w", containerID, err)
+	}
+	return nil
+}
+
+// SetNetem replaces any traffic shaping on the container's network interface
+// with the given latency, jitter and percentage of packet loss, applied to outgoing traffic.
+// A zero latency or loss percentage adds no latency or loss respectively;
+// jitter only applies with a non-zero latency.
+func SetNetem(ctx context.Context, log *zap.Logger, cli *client.Client, testName, containerID string, latency, jitter time.Duration, lossPercent float64) error {
+	cmd := []string{"tc", "qdisc", "replace", "dev", "eth0", "root", "netem"}
+	if latency > 0 {
+		cmd = append(cmd, "delay", formatNetemDuration(latency))
+		if jitter > 0 {
+			cmd = append(cmd, formatNetemDuration(jitter))
+		}
+	}
+	if lossPercent > 0 {
+		cmd = append(cmd, "loss", strconv.FormatFloat(lossPercent, 'f', -1, 64)+"%")
+	}
+	return runInNetworkNamespace(ctx, log, cli, testName, containerID, cmd)
+}
+
+// ClearNetem removes traffic shaping set by SetNetem.
+func ClearNetem(ctx context.Context, log *zap.Logger, cli *client.Client, testName, containerID string) error {
+	// Deleting the root qdisc fails if there is nothing to delete, so check first.
+	cmd := []string{"sh", "-c", "if tc qdisc show dev eth0 | grep -q netem; then tc qdisc del dev eth0 root; fi"}
+	return runInNetworkNamespace(ctx, log, cli, testName, containerID, cmd)
+}
+
+// formatNetemDuration formats d in microseconds, the finest unit tc accepts.
+func formatNetemDuration(d time.Duration) string {
+	return strconv.FormatInt(d.Microseconds(), 10) + "us"
+}
+
+// runInNetworkNamespace runs cmd to completion in a temporary container
+// sharing the network namespace of the container with ID containerID.
+func runInNetworkNamespace(ctx context.Context, log *zap.Logger, cli *client.Client, testName, containerID string, cmd []string) error {
+	if err := ensureImage(ctx, cli, netemImageRef); err != nil {
+		return err
+	}
+
+	containerName := fmt.Sprintf("ibctest-netem-%d-%s", time.Now().UnixNano(), RandLowerCaseLetterString(5))
+	cc, err := cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: netemImageRef,
+
+			Entrypoint: []string{},
+			Cmd:        cmd,
+
+			Labels: map[string]string{CleanupLabel: testName},
+		},
+		&container.HostConfig{
+			NetworkMode: container.NetworkMode("container:" + containerID),
+			CapAdd:      []string{"NET_ADMIN"},
+		},
+		nil,
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("creating netem container: %w", err)
+	}
+
+	defer func() {
+		if err := cli.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{
+			Force: true,
+		}); err != nil {
+			log.Warn("Failed to remove netem container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	if err := cli.ContainerStart(ctx, cc.ID, types.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("starting netem container: %w", err)
+	}
+
+	waitCh, errCh := cli.ContainerWait(ctx, cc.ID, container.WaitConditionNotRunning)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	case res := <-waitCh:
+		if res.Error != nil {
+			return fmt.Errorf("waiting for netem container: %s", res.Error.Message)
+		}
+		if res.StatusCode != 0 {
+			return fmt.Errorf("%s exited %d: %s", strings.Join(cmd, " "), res.StatusCode, containerOutput(ctx, cli, cc.ID))
+		}
+	}
+	return nil
+}
+
+// containerOutput returns the combined stdout and stderr of a container, for error messages.
+func containerOutput(ctx context.Context, cli *client.Client, containerID string) string {
+	rc, err := cli.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+	})
+	if err != nil {
+		return fmt.Sprintf("(failed to get logs: %v)", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	var buf bytes.Buffer
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	if _, err := stdcopy.StdCopy(&buf, &buf, rc); err != nil {
+		return fmt.Sprintf("(failed to read logs: %v)", err)
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// ensureImage pulls the image with the given reference if it is not already present.
+func ensureImage(ctx context.Context, cli *client.Client, ref string) error {
+	images, err := cli.ImageList(ctx, types.ImageListOptions{
+		Filters: filters.NewArgs(filters.Arg("reference", ref)),
+	})
+	if err != nil {
+		return fmt.Errorf("listing images to check %s presence: %w", ref, err)
+	}
+	if len(images) > 0 {
+		return nil
+	}
+
+	rc, err := cli.ImagePull(ctx, ref, types.ImagePullOptions{})
+	if err != nil {
+		return fmt.Errorf("pulling %s: %w", ref, err)
+	}
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
+	return nil
+}
@@ -0,0 +1,71 @@
+package dockerutil_test
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/internal/dockerutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestNetworkFaults(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	t.Parallel()
+
+	cli, network := ibctest.DockerSetup(t)
+
+	ctx := context.Background()
+	log := zaptest.NewLogger(t)
+	testName := t.Name()
+	img := dockerutil.NewImage(log, cli, network, testName, "busybox", "stable")
+
+	target, err := img.Start(ctx, []string{"sleep", "600"}, dockerutil.ContainerOptions{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = target.Stop(10 * time.Second) })
+
+	pingMs := func() (float64, error) {
+		res := img.Run(ctx, []string{"ping", "-c", "1", "-W", "2", target.Name}, dockerutil.ContainerOptions{})
+		if res.Err != nil {
+			return 0, res.Err
+		}
+		m := regexp.MustCompile(`time=([0-9.]+) ms`).FindSubmatch(res.Stdout)
+		require.NotNil(t, m, "unexpected ping output: %s", res.Stdout)
+		return strconv.ParseFloat(string(m[1]), 64)
+	}
+
+	_, err = pingMs()
+	require.NoError(t, err)
+
+	t.Run("partition", func(t *testing.T) {
+		require.NoError(t, dockerutil.DisconnectNetwork(ctx, cli, network, target.Name))
+		_, err := pingMs()
+		require.Error(t, err)
+
+		require.NoError(t, dockerutil.ConnectNetwork(ctx, cli, network, target.Name))
+		_, err = pingMs()
+		require.NoError(t, err)
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		require.NoError(t, dockerutil.SetNetem(ctx, log, cli, testName, target.Name, 300*time.Millisecond, 0, 0))
+		ms, err := pingMs()
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, ms, 300.0)
+
+		require.NoError(t, dockerutil.ClearNetem(ctx, log, cli, testName, target.Name))
+		ms, err = pingMs()
+		require.NoError(t, err)
+		require.Less(t, ms, 300.0)
+
+		// Clearing again is a no-op.
+		require.NoError(t, dockerutil.ClearNetem(ctx, log, cli, testName, target.Name))
+	})
+}
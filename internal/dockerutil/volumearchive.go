@@ -0,0 +1,119 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+)
+
+// VolumeArchiver allows saving the entire content of a Docker volume to a tar archive,
+// and restoring it into another volume.
+type VolumeArchiver struct {
+	log *zap.Logger
+
+	cli *client.Client
+
+	testName string
+}
+
+// NewVolumeArchiver returns a new VolumeArchiver.
+func NewVolumeArchiver(log *zap.Logger, cli *client.Client, testName string) *VolumeArchiver {
+	return &VolumeArchiver{log: log, cli: cli, testName: testName}
+}
+
+// volumeArchiveMountPath is where the volume is mounted in the container used to archive or restore it.
+// Entries of the archives are relative to its parent directory, so they begin with its base name.
+const (
+	volumeArchiveMountParent = "/mnt"
+	volumeArchiveMountPath   = volumeArchiveMountParent + "/dockervolume"
+)
+
+// Archive writes a tar archive of the content of the volume specified by volumeName to w.
+// The volume should not be in use by a running container, so that its content is consistent.
+func (a *VolumeArchiver) Archive(ctx context.Context, volumeName string, w io.Writer) error {
+	id, cleanup, err := a.createContainer(ctx, "archive", volumeName)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	rc, _, err := a.cli.CopyFromContainer(ctx, id, volumeArchiveMountPath)
+	if err != nil {
+		return fmt.Errorf("copying from container: %w", err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	if _, err := io.Copy(w, rc); err != nil {
+		return fmt.Errorf("writing volume archive: %w", err)
+	}
+	return nil
+}
+
+// Restore extracts a tar archive written by Archive into the volume specified by volumeName,
+// preserving the owners of the archived files.
+func (a *VolumeArchiver) Restore(ctx context.Context, volumeName string, r io.Reader) error {
+	id, cleanup, err := a.createContainer(ctx, "restore", volumeName)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := a.cli.CopyToContainer(
+		ctx,
+		id,
+		volumeArchiveMountParent,
+		r,
+		types.CopyToContainerOptions{CopyUIDGID: true},
+	); err != nil {
+		return fmt.Errorf("copying volume archive to container: %w", err)
+	}
+	return nil
+}
+
+// createContainer creates, without starting it, a container mounting the volume at volumeArchiveMountPath.
+// The returned function removes the container.
+func (a *VolumeArchiver) createContainer(ctx context.Context, op, volumeName string) (string, func(), error) {
+	if err := ensureBusybox(ctx, a.cli); err != nil {
+		return "", nil, err
+	}
+
+	containerName := fmt.Sprintf("ibctest-%svolume-%d-%s", op, time.Now().UnixNano(), RandLowerCaseLetterString(5))
+
+	cc, err := a.cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: busyboxRef,
+
+			// Use root user to avoid permission issues when reading or writing files in the volume.
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: a.testName},
+		},
+		&container.HostConfig{
+			Binds:      []string{volumeName + ":" + volumeArchiveMountPath},
+			AutoRemove: true,
+		},
+		nil, // No networking necessary.
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("creating container: %w", err)
+	}
+
+	return cc.ID, func() {
+		if err := a.cli.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{
+			Force: true,
+		}); err != nil {
+			a.log.Warn("Failed to remove volume archive container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}, nil
+}
@@ -0,0 +1,52 @@
+package dockerutil_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/internal/dockerutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestVolumeArchiver(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	t.Parallel()
+
+	cli, _ := ibctest.DockerSetup(t)
+
+	ctx := context.Background()
+	newVolume := func() string {
+		v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
+			Labels: map[string]string{dockerutil.CleanupLabel: t.Name()},
+		})
+		require.NoError(t, err)
+		return v.Name
+	}
+	src, dst := newVolume(), newVolume()
+
+	log := zaptest.NewLogger(t)
+	fw := dockerutil.NewFileWriter(log, cli, t.Name())
+	require.NoError(t, fw.WriteFile(ctx, src, "hello.txt", []byte("hello world")))
+	require.NoError(t, fw.WriteFile(ctx, src, "a/b/c.txt", []byte(":D")))
+
+	a := dockerutil.NewVolumeArchiver(log, cli, t.Name())
+	var buf bytes.Buffer
+	require.NoError(t, a.Archive(ctx, src, &buf))
+	require.NoError(t, a.Restore(ctx, dst, &buf))
+
+	fr := dockerutil.NewFileRetriever(log, cli, t.Name())
+	content, err := fr.SingleFileContent(ctx, dst, "hello.txt")
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(content))
+
+	content, err = fr.SingleFileContent(ctx, dst, "a/b/c.txt")
+	require.NoError(t, err)
+	require.Equal(t, ":D", string(content))
+}
@@ -0,0 +1,263 @@
+package ibctest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/internal/blockdb"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+)
+
+// PacketHop is the progress of a packet over a single channel, as traced by (*Interchain).TracePacket.
+type PacketHop struct {
+	Packet ibc.Packet
+
+	// Src is the chain that sent the packet; Dst is the chain that received it.
+	Src, Dst ibc.Chain
+
+	// Heights of the blocks on Src including the packet send and its acknowledgement,
+	// and of the block on Dst including the packet receipt.
+	SendHeight, RecvHeight, AckHeight uint64
+
+	// Time from the start of the trace until the receipt and the acknowledgement were observed.
+	RecvElapsed, AckElapsed time.Duration
+}
+
+// PacketTrace is the route of a packet through an Interchain,
+// including any packets forwarded by middleware on receipt.
+type PacketTrace struct {
+	// Hops in the order the packet took them.
+	// The last hop is the one delivering the packet to its final destination.
+	Hops []PacketHop
+}
+
+// TracePacket follows the packet sent in tx on the src chain through every hop of the Interchain,
+// until the packet and every packet forwarded on its receipt are received and acknowledged.
+// A packet sent in the same transaction that receives a packet, such as by packet forward middleware,
+// is treated as the next hop.
+//
+// Each step waits up to maxBlocks blocks of the chain on which it is expected.
+// TracePacket returns the partial trace, along with an error naming the stalled hop,
+// if a step does not happen in time or a packet times out.
+//
+// TracePacket should be called soon after the packet is sent, while the relayers are running.
+// Receipts which happened before the trace started are found by searching back
+// as many blocks on every chain as the src chain has produced since tx,
+// which assumes the chains produce blocks at similar rates.
+func (ic *Interchain) TracePacket(ctx context.Context, rep *testreporter.RelayerExecReporter, src ibc.Chain, tx ibc.Tx, maxBlocks uint64) (PacketTrace, error) {
+	start := time.Now()
+
+	srcHeight, err := src.Height(ctx)
+	if err != nil {
+		return PacketTrace{}, fmt.Errorf("failed to get height of %s: %w", ic.chains[src], err)
+	}
+	var lookback uint64
+	if srcHeight > tx.Height {
+		lookback = srcHeight - tx.Height
+	}
+
+	// Lowest height on each chain at which a receipt of any traced packet may be found.
+	searchHeights := make(map[ibc.Chain]uint64, len(ic.chains))
+	for c := range ic.chains {
+		h, err := c.Height(ctx)
+		if err != nil {
+			return PacketTrace{}, fmt.Errorf("failed to get height of %s: %w", ic.chains[c], err)
+		}
+		// Go back one more block, in case the chains' blocks are not aligned.
+		if h > lookback+1 {
+			searchHeights[c] = h - lookback - 1
+		} else {
+			searchHeights[c] = 1
+		}
+	}
+
+	var trace PacketTrace
+	hop := PacketHop{Packet: tx.Packet, Src: src, SendHeight: tx.Height}
+
+	// Follow the packet forwards until it is received without being forwarded.
+	for {
+		n := len(trace.Hops) + 1
+
+		dst, err := ic.packetDestination(ctx, rep, hop.Src, hop.Packet)
+		if err != nil {
+			return trace, fmt.Errorf("hop %d: %w", n, err)
+		}
+		hop.Dst = dst
+
+		var forwarded *ibc.Packet
+		hop.RecvHeight, err = scanTxs(ctx, dst, searchHeights[dst], maxBlocks, func(tx blockdb.Tx) (bool, error) {
+			if !hasPacketEvent(tx, "recv_packet", hop.Packet) {
+				return false, nil
+			}
+			for _, ev := range tx.Events {
+				if ev.Type != "send_packet" {
+					continue
+				}
+				p, err := packetFromEvent(ev)
+				if err != nil {
+					return false, err
+				}
+				forwarded = &p
+			}
+			return true, nil
+		})
+		if err != nil {
+			return trace, fmt.Errorf("hop %d: packet %d on %s/%s from %s not received on %s: %w",
+				n, hop.Packet.Sequence, hop.Packet.SourcePort, hop.Packet.SourceChannel, ic.chains[hop.Src], ic.chains[dst], err,
+			)
+		}
+		hop.RecvElapsed = time.Since(start)
+
+		trace.Hops = append(trace.Hops, hop)
+		if forwarded == nil {
+			break
+		}
+		hop = PacketHop{Packet: *forwarded, Src: dst, SendHeight: hop.RecvHeight}
+	}
+
+	// Follow the acknowledgements back, starting from the final destination,
+	// because forwarding middleware may only acknowledge a packet once the forwarded packet is acknowledged.
+	for i := len(trace.Hops) - 1; i >= 0; i-- {
+		hop := &trace.Hops[i]
+
+		var timedOut bool
+		ackHeight, err := scanTxs(ctx, hop.Src, hop.SendHeight, maxBlocks, func(tx blockdb.Tx) (bool, error) {
+			timedOut = hasPacketEvent(tx, "timeout_packet", hop.Packet)
+			return timedOut || hasPacketEvent(tx, "acknowledge_packet", hop.Packet), nil
+		})
+		if err == nil && timedOut {
+			err = errors.New("packet timed out")
+		}
+		if err != nil {
+			return trace, fmt.Errorf("hop %d: packet %d on %s/%s from %s to %s not acknowledged: %w",
+				i+1, hop.Packet.Sequence, hop.Packet.SourcePort, hop.Packet.SourceChannel, ic.chains[hop.Src], ic.chains[hop.Dst], err,
+			)
+		}
+		hop.AckHeight = ackHeight
+		hop.AckElapsed = time.Since(start)
+	}
+
+	return trace, nil
+}
+
+// packetDestination returns the chain receiving the packet sent from src,
+// by matching the packet's channels with the channels of the chains linked to src.
+func (ic *Interchain) packetDestination(ctx context.Context, rep *testreporter.RelayerExecReporter, src ibc.Chain, packet ibc.Packet) (ibc.Chain, error) {
+	for rp, chains := range ic.links {
+		var dst ibc.Chain
+		switch src {
+		case chains[0]:
+			dst = chains[1]
+		case chains[1]:
+			dst = chains[0]
+		default:
+			continue
+		}
+
+		channels, err := rp.Relayer.GetChannels(ctx, rep, ic.chains[dst])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get channels on %s: %w", ic.chains[dst], err)
+		}
+		for _, ch := range channels {
+			if ch.PortID == packet.DestPort && ch.ChannelID == packet.DestChannel &&
+				ch.Counterparty.PortID == packet.SourcePort && ch.Counterparty.ChannelID == packet.SourceChannel {
+				return dst, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no chain linked to %s has channel %s/%s", ic.chains[src], packet.DestPort, packet.DestChannel)
+}
+
+// scanTxs calls match with the transactions in the blocks of c from startHeight, waiting for new blocks,
+// until match returns true, and returns the height of the matching transaction.
+// It returns an error wrapping test.ErrNotFound if no transaction matches in maxBlocks blocks past startHeight.
+func scanTxs(ctx context.Context, c ibc.Chain, startHeight, maxBlocks uint64, match func(blockdb.Tx) (bool, error)) (uint64, error) {
+	finder, ok := c.(blockdb.TxFinder)
+	if !ok {
+		return 0, fmt.Errorf("chain %s does not support finding transactions", c.Config().ChainID)
+	}
+
+	maxHeight := startHeight + maxBlocks
+	for h := startHeight; h <= maxHeight; {
+		cur, err := c.Height(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if h > cur {
+			if err := test.WaitForBlocks(ctx, 1, c); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		txs, err := finder.FindTxs(ctx, h)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find transactions at height %d: %w", h, err)
+		}
+		for _, tx := range txs {
+			ok, err := match(tx)
+			if err != nil {
+				return 0, fmt.Errorf("transaction at height %d: %w", h, err)
+			}
+			if ok {
+				return h, nil
+			}
+		}
+		h++
+	}
+	return 0, fmt.Errorf("searched heights %d to %d: %w", startHeight, maxHeight, test.ErrNotFound)
+}
+
+// hasPacketEvent reports whether tx has an event of eventType for the packet,
+// identified by its source port, source channel and sequence.
+func hasPacketEvent(tx blockdb.Tx, eventType string, packet ibc.Packet) bool {
+	seq := strconv.FormatUint(packet.Sequence, 10)
+	for _, ev := range tx.Events {
+		if ev.Type != eventType {
+			continue
+		}
+		attrs := eventAttributes(ev)
+		if attrs["packet_src_port"] == packet.SourcePort &&
+			attrs["packet_src_channel"] == packet.SourceChannel &&
+			attrs["packet_sequence"] == seq {
+			return true
+		}
+	}
+	return false
+}
+
+// packetFromEvent returns the packet described by the attributes of a packet event such as send_packet.
+func packetFromEvent(ev blockdb.Event) (ibc.Packet, error) {
+	attrs := eventAttributes(ev)
+	seq, err := strconv.ParseUint(attrs["packet_sequence"], 10, 64)
+	if err != nil {
+		return ibc.Packet{}, fmt.Errorf("invalid packet sequence %q: %w", attrs["packet_sequence"], err)
+	}
+	timeoutTs, err := strconv.ParseUint(attrs["packet_timeout_timestamp"], 10, 64)
+	if err != nil {
+		return ibc.Packet{}, fmt.Errorf("invalid packet timeout timestamp %q: %w", attrs["packet_timeout_timestamp"], err)
+	}
+	return ibc.Packet{
+		Sequence:         seq,
+		SourcePort:       attrs["packet_src_port"],
+		SourceChannel:    attrs["packet_src_channel"],
+		DestPort:         attrs["packet_dst_port"],
+		DestChannel:      attrs["packet_dst_channel"],
+		Data:             []byte(attrs["packet_data"]),
+		TimeoutHeight:    attrs["packet_timeout_height"],
+		TimeoutTimestamp: ibc.Nanoseconds(timeoutTs),
+	}, nil
+}
+
+func eventAttributes(ev blockdb.Event) map[string]string {
+	attrs := make(map[string]string, len(ev.Attributes))
+	for _, a := range ev.Attributes {
+		attrs[a.Key] = a.Value
+	}
+	return attrs
+}
@@ -0,0 +1,168 @@
+package ibctest_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/internal/blockdb"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// traceChain is a chain whose height advances every time it is queried.
+type traceChain struct {
+	ibc.Chain
+
+	id     string
+	height uint64
+	txs    map[uint64][]blockdb.Tx
+}
+
+func (c *traceChain) Config() ibc.ChainConfig {
+	return ibc.ChainConfig{ChainID: c.id, Name: c.id}
+}
+
+func (c *traceChain) Height(ctx context.Context) (uint64, error) {
+	c.height++
+	return c.height, nil
+}
+
+func (c *traceChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
+	return c.txs[height], nil
+}
+
+type traceRelayer struct {
+	ibc.Relayer
+
+	channels map[string][]ibc.ChannelOutput
+}
+
+func (r *traceRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
+	return r.channels[chainID], nil
+}
+
+func packetEvent(eventType string, p ibc.Packet) blockdb.Event {
+	return blockdb.Event{
+		Type: eventType,
+		Attributes: []blockdb.EventAttribute{
+			{Key: "packet_sequence", Value: strconv.FormatUint(p.Sequence, 10)},
+			{Key: "packet_src_port", Value: p.SourcePort},
+			{Key: "packet_src_channel", Value: p.SourceChannel},
+			{Key: "packet_dst_port", Value: p.DestPort},
+			{Key: "packet_dst_channel", Value: p.DestChannel},
+			{Key: "packet_timeout_height", Value: p.TimeoutHeight},
+			{Key: "packet_timeout_timestamp", Value: strconv.FormatUint(uint64(p.TimeoutTimestamp), 10)},
+		},
+	}
+}
+
+func TestInterchain_TracePacket(t *testing.T) {
+	ctx := context.Background()
+
+	// A packet from osmosis to gaia, forwarded by gaia to juno.
+	first := ibc.Packet{
+		Sequence:         3,
+		SourcePort:       "transfer",
+		SourceChannel:    "channel-0",
+		DestPort:         "transfer",
+		DestChannel:      "channel-0",
+		TimeoutHeight:    "0-100",
+		TimeoutTimestamp: 1,
+	}
+	forwarded := ibc.Packet{
+		Sequence:         7,
+		SourcePort:       "transfer",
+		SourceChannel:    "channel-1",
+		DestPort:         "transfer",
+		DestChannel:      "channel-0",
+		TimeoutHeight:    "0-100",
+		TimeoutTimestamp: 1,
+	}
+
+	newInterchain := func() (*ibctest.Interchain, *traceChain, *traceChain, *traceChain) {
+		osmosis := &traceChain{id: "osmosis", height: 10, txs: map[uint64][]blockdb.Tx{
+			30: {{Events: []blockdb.Event{packetEvent("acknowledge_packet", first)}}},
+		}}
+		gaia := &traceChain{id: "gaia", height: 10, txs: map[uint64][]blockdb.Tx{
+			// Another packet on the same channel, and the receipt of the packet, forwarding it.
+			12: {{Events: []blockdb.Event{packetEvent("recv_packet", ibc.Packet{Sequence: 2, SourcePort: "transfer", SourceChannel: "channel-0"})}}},
+			14: {{Events: []blockdb.Event{packetEvent("recv_packet", first), packetEvent("send_packet", forwarded)}}},
+			25: {{Events: []blockdb.Event{packetEvent("acknowledge_packet", forwarded)}}},
+		}}
+		juno := &traceChain{id: "juno", height: 10, txs: map[uint64][]blockdb.Tx{
+			20: {{Events: []blockdb.Event{packetEvent("recv_packet", forwarded)}}},
+		}}
+
+		r := &traceRelayer{channels: map[string][]ibc.ChannelOutput{
+			"osmosis": {
+				{PortID: "transfer", ChannelID: "channel-0", Counterparty: ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-0"}},
+			},
+			"gaia": {
+				{PortID: "transfer", ChannelID: "channel-0", Counterparty: ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-0"}},
+				{PortID: "transfer", ChannelID: "channel-1", Counterparty: ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-0"}},
+			},
+			"juno": {
+				{PortID: "transfer", ChannelID: "channel-0", Counterparty: ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-1"}},
+			},
+		}}
+
+		ic := ibctest.NewInterchain().
+			AddChain(osmosis).
+			AddChain(gaia).
+			AddChain(juno).
+			AddRelayer(r, "r").
+			AddLink(ibctest.InterchainLink{Chain1: osmosis, Chain2: gaia, Relayer: r, Path: "osmohub"}).
+			AddLink(ibctest.InterchainLink{Chain1: gaia, Chain2: juno, Relayer: r, Path: "junohub"})
+		return ic, osmosis, gaia, juno
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		ic, osmosis, gaia, juno := newInterchain()
+		eRep := testreporter.NewNopReporter().RelayerExecReporter(t)
+
+		trace, err := ic.TracePacket(ctx, eRep, osmosis, ibc.Tx{Height: 9, Packet: first}, 50)
+		require.NoError(t, err)
+		require.Len(t, trace.Hops, 2)
+
+		hop := trace.Hops[0]
+		require.Equal(t, first, hop.Packet)
+		require.Same(t, osmosis, hop.Src)
+		require.Same(t, gaia, hop.Dst)
+		require.Equal(t, uint64(9), hop.SendHeight)
+		require.Equal(t, uint64(14), hop.RecvHeight)
+		require.Equal(t, uint64(30), hop.AckHeight)
+
+		hop = trace.Hops[1]
+		require.Equal(t, forwarded.Sequence, hop.Packet.Sequence)
+		require.Equal(t, forwarded.SourceChannel, hop.Packet.SourceChannel)
+		require.Same(t, gaia, hop.Src)
+		require.Same(t, juno, hop.Dst)
+		require.Equal(t, uint64(14), hop.SendHeight)
+		require.Equal(t, uint64(20), hop.RecvHeight)
+		require.Equal(t, uint64(25), hop.AckHeight)
+	})
+
+	t.Run("stalled hop", func(t *testing.T) {
+		ic, osmosis, _, juno := newInterchain()
+		delete(juno.txs, 20)
+		eRep := testreporter.NewNopReporter().RelayerExecReporter(t)
+
+		trace, err := ic.TracePacket(ctx, eRep, osmosis, ibc.Tx{Height: 9, Packet: first}, 20)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "hop 2: packet 7 on transfer/channel-1 from gaia not received on juno")
+		require.Len(t, trace.Hops, 1)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ic, osmosis, gaia, _ := newInterchain()
+		gaia.txs[25] = []blockdb.Tx{{Events: []blockdb.Event{packetEvent("timeout_packet", forwarded)}}}
+		eRep := testreporter.NewNopReporter().RelayerExecReporter(t)
+
+		_, err := ic.TracePacket(ctx, eRep, osmosis, ibc.Tx{Height: 9, Packet: first}, 50)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "hop 2: packet 7 on transfer/channel-1 from gaia to juno not acknowledged: packet timed out")
+	})
+}
@@ -18,6 +18,17 @@ const (
 	// Whether the relayer supports a one-off flush packets or flush acknowledgements command.
 	FlushPackets
 	FlushAcknowledgements
+
+	// Whether the relayer, while running, detects conflicting headers submitted to a light client
+	// and submits evidence of the misbehaviour to freeze the client.
+	Misbehaviour
+
+	// Whether the relayer supports closing a channel with the CloseChannel method.
+	CloseChannel
+
+	// Whether the relayer relays packets on channels with the ICS-29 fee middleware,
+	// such that the fees paid for the packets go to the payees registered for the relayer.
+	FeeMiddleware
 )
 
 // FullCapabilities returns a mapping of all known relayer features to true,
@@ -31,5 +42,11 @@ func FullCapabilities() map[Capability]bool {
 
 		FlushPackets:          true,
 		FlushAcknowledgements: true,
+
+		Misbehaviour: true,
+
+		CloseChannel: true,
+
+		FeeMiddleware: true,
 	}
 }
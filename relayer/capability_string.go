@@ -12,11 +12,14 @@ func _() {
 	_ = x[HeightTimeout-1]
 	_ = x[FlushPackets-2]
 	_ = x[FlushAcknowledgements-3]
+	_ = x[Misbehaviour-4]
+	_ = x[CloseChannel-5]
+	_ = x[FeeMiddleware-6]
 }
 
-const _Capability_name = "TimestampTimeoutHeightTimeoutFlushPacketsFlushAcknowledgements"
+const _Capability_name = "TimestampTimeoutHeightTimeoutFlushPacketsFlushAcknowledgementsMisbehaviourCloseChannelFeeMiddleware"
 
-var _Capability_index = [...]uint8{0, 16, 29, 41, 62}
+var _Capability_index = [...]uint8{0, 16, 29, 41, 62, 74, 86, 99}
 
 func (i Capability) String() string {
 	if i < 0 || i >= Capability(len(_Capability_index)-1) {
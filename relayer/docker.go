@@ -4,10 +4,13 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -16,6 +19,7 @@ import (
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
 	"github.com/strangelove-ventures/ibctest/ibc"
 	"github.com/strangelove-ventures/ibctest/internal/dockerutil"
 	"go.uber.org/zap"
@@ -35,14 +39,42 @@ type DockerRelayer struct {
 
 	testName string
 
+	// Random suffix distinguishing the containers and log files of relayers
+	// of the same kind in the same test, such as two relayers sharing a path.
+	instance string
+
 	customImage *ibc.DockerImage
 	pullImage   bool
 
 	// The ID of the container created by StartRelayer.
 	containerID string
 
+	// Guards hostMetricsAddr, logsDone and ready, which are reset by StopRelayer
+	// while WaitUntilReady or Metrics may be reading them.
+	mu sync.Mutex
+
+	// Whether the container created by StartRelayer serves metrics,
+	// and the host address they are published on once it has started.
+	metrics         bool
+	hostMetricsAddr string
+
+	// Receivers of the lines logged by the container created by StartRelayer,
+	// whose logs are also written to the file at LogFilePath.
+	logSinks       []LogSink
+	logFileCreated bool
+
+	// Closed when the logs of the container created by StartRelayer end.
+	logsDone chan struct{}
+
+	// Closed once the container created by StartRelayer has logged that it is relaying.
+	ready chan struct{}
+
 	// wallets contains a mapping of chainID to relayer wallet
 	wallets map[string]ibc.RelayerWallet
+
+	// IDs of the chains of each path added by GeneratePath or LinkExistingPath,
+	// so that StartRelayer knows which chains must be ready.
+	pathChains map[string][2]string
 }
 
 var _ ibc.Relayer = (*DockerRelayer)(nil)
@@ -61,8 +93,11 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 		pullImage: true,
 
 		testName: testName,
+		instance: dockerutil.RandLowerCaseLetterString(6),
 
 		wallets: map[string]ibc.RelayerWallet{},
+
+		pathChains: map[string][2]string{},
 	}
 
 	for _, opt := range options {
@@ -71,6 +106,11 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 			r.customImage = &o.DockerImage
 		case RelayerOptionImagePull:
 			r.pullImage = o.Pull
+		case RelayerOptionMetrics:
+			if _, ok := c.(MetricsCommander); !ok {
+				return nil, fmt.Errorf("relayer %s does not serve metrics", c.Name())
+			}
+			r.metrics = true
 		}
 	}
 
@@ -214,6 +254,12 @@ func (r *DockerRelayer) GetWallet(chainID string) (ibc.RelayerWallet, bool) {
 	return wallet, ok
 }
 
+func (r *DockerRelayer) CloseChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, portID, channelID string) error {
+	cmd := r.c.CloseChannel(pathName, portID, channelID, r.NodeHome())
+	res := r.Exec(ctx, rep, cmd, nil)
+	return res.Err
+}
+
 func (r *DockerRelayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
 	cmd := r.c.CreateChannel(pathName, opts, r.NodeHome())
 	res := r.Exec(ctx, rep, cmd, nil)
@@ -247,7 +293,11 @@ func (r *DockerRelayer) FlushPackets(ctx context.Context, rep ibc.RelayerExecRep
 func (r *DockerRelayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
 	cmd := r.c.GeneratePath(srcChainID, dstChainID, pathName, r.NodeHome())
 	res := r.Exec(ctx, rep, cmd, nil)
-	return res.Err
+	if res.Err != nil {
+		return res.Err
+	}
+	r.pathChains[pathName] = [2]string{srcChainID, dstChainID}
+	return nil
 }
 
 func (r *DockerRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
@@ -281,6 +331,35 @@ func (r *DockerRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporte
 	return res.Err
 }
 
+func (r *DockerRelayer) LinkExistingPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, src, dst ibc.PathEnd) error {
+	// Like the chain configuration, the path is added from a file on disk.
+	pathConfigFile := pathName + ".path.config"
+
+	pathConfigContainerFilePath := path.Join(r.NodeHome(), pathConfigFile)
+
+	configContent, err := r.c.PathConfigContent(src, dst)
+	if err != nil {
+		return fmt.Errorf("failed to generate path config content: %w", err)
+	}
+
+	tar, err := r.generateConfigTar(pathConfigFile, configContent)
+	if err != nil {
+		return fmt.Errorf("generating tar for path configuration: %w", err)
+	}
+
+	if err := r.untarIntoNodeHome(ctx, tar); err != nil {
+		return err // Already wrapped.
+	}
+
+	cmd := r.c.AddPath(src.ChainID, dst.ChainID, pathName, pathConfigContainerFilePath, r.NodeHome())
+	res := r.Exec(ctx, rep, cmd, nil)
+	if res.Err != nil {
+		return res.Err
+	}
+	r.pathChains[pathName] = [2]string{src.ChainID, dst.ChainID}
+	return nil
+}
+
 func (r *DockerRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string, env []string) dockerutil.ContainerExecResult {
 	job := dockerutil.NewImage(r.log, r.client, r.networkID, r.testName, r.containerImage().Repository, r.containerImage().Version)
 	opts := dockerutil.ContainerOptions{
@@ -331,15 +410,93 @@ func (r *DockerRelayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecRe
 	return res.Err
 }
 
-func (r *DockerRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
-	return r.createNodeContainer(ctx, pathName)
+func (r *DockerRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	if err := r.createNodeContainer(ctx, pathNames); err != nil {
+		return err
+	}
+
+	// Follow the logs independently of ctx; they end when the container is stopped.
+	logsDone, ready := make(chan struct{}), make(chan struct{})
+	r.mu.Lock()
+	r.logsDone, r.ready = logsDone, ready
+	r.mu.Unlock()
+	go r.streamLogs(context.Background(), rep, r.containerName(pathNames), r.pathChainIDs(pathNames), logsDone, ready)
+	return nil
+}
+
+// pathChainIDs returns the IDs of the chains of the given paths, as recorded by GeneratePath or LinkExistingPath.
+func (r *DockerRelayer) pathChainIDs(pathNames []string) map[string]bool {
+	chainIDs := make(map[string]bool, 2*len(pathNames))
+	for _, p := range pathNames {
+		chains, ok := r.pathChains[p]
+		if !ok {
+			continue
+		}
+		chainIDs[chains[0]] = true
+		chainIDs[chains[1]] = true
+	}
+	return chainIDs
+}
+
+// WaitUntilReady blocks until the relayer started by StartRelayer has logged that it is relaying,
+// and, if it serves metrics, until they can be scraped.
+// It returns an error if the relayer stops before it is ready.
+func (r *DockerRelayer) WaitUntilReady(ctx context.Context) error {
+	r.mu.Lock()
+	ready, logsDone := r.ready, r.logsDone
+	r.mu.Unlock()
+	if ready == nil {
+		return errRelayerNotStarted
+	}
+
+	select {
+	case <-ready:
+	case <-logsDone:
+		// The last lines may have shown that the relayer was ready before it stopped.
+		select {
+		case <-ready:
+		default:
+			return fmt.Errorf("relayer %s stopped before it was ready", r.Name())
+		}
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for relayer %s to log that it is relaying: %w", r.Name(), ctx.Err())
+	}
+
+	if !r.metrics {
+		return nil
+	}
+	for {
+		_, err := r.Metrics(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-time.After(250 * time.Millisecond):
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for relayer %s to serve metrics: %w", r.Name(), err)
+		}
+	}
 }
 
 func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	// The metrics are gone once the container stops.
+	r.trackEfficiency(ctx, rep)
+
 	if err := r.stopContainer(ctx); err != nil {
 		return err
 	}
 
+	r.mu.Lock()
+	logsDone := r.logsDone
+	r.mu.Unlock()
+	if logsDone != nil {
+		select {
+		case <-logsDone:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
 	stdoutBuf := new(bytes.Buffer)
 	stderrBuf := new(bytes.Buffer)
 	rc, err := r.client.ContainerLogs(ctx, r.containerID, types.ContainerLogsOptions{
@@ -362,6 +519,11 @@ func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecRepo
 	stdout := stdoutBuf.String()
 	stderr := stderrBuf.String()
 
+	r.mu.Lock()
+	r.hostMetricsAddr = ""
+	r.ready = nil
+	r.mu.Unlock()
+
 	c, err := r.client.ContainerInspect(ctx, r.containerID)
 	if err != nil {
 		return fmt.Errorf("StopRelayer: inspecting container: %w", err)
@@ -426,15 +588,23 @@ func (r *DockerRelayer) pullContainerImageIfNecessary(containerImage ibc.DockerI
 	return nil
 }
 
-func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathName string) error {
+func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathNames []string) error {
 	containerImage := r.containerImage()
-	containerName := fmt.Sprintf("%s-%s", r.c.Name(), pathName)
-	cmd := r.c.StartRelayer(pathName, r.NodeHome())
+	containerName := r.containerName(pathNames)
+	cmd := r.c.StartRelayer(r.NodeHome(), pathNames...)
 	r.log.Info(
 		"Running command",
 		zap.String("command", strings.Join(cmd, " ")),
 		zap.String("container", containerName),
 	)
+
+	var metricsPort string
+	exposedPorts := nat.PortSet{}
+	if r.metrics {
+		metricsPort = r.c.(MetricsCommander).MetricsPort()
+		exposedPorts[nat.Port(metricsPort)] = struct{}{}
+	}
+
 	cc, err := r.client.ContainerCreate(
 		ctx,
 		&container.Config{
@@ -443,14 +613,17 @@ func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathName string
 			Entrypoint: []string{},
 			Cmd:        cmd,
 
-			Hostname: r.HostName(pathName),
+			Hostname: r.HostName(strings.Join(pathNames, "_")),
 			User:     r.c.DockerUser(),
 
 			Labels: map[string]string{dockerutil.CleanupLabel: r.testName},
+
+			ExposedPorts: exposedPorts,
 		},
 		&container.HostConfig{
-			Binds:      r.Bind(),
-			AutoRemove: false,
+			Binds:           r.Bind(),
+			PublishAllPorts: r.metrics,
+			AutoRemove:      false,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -465,7 +638,25 @@ func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathName string
 	}
 
 	r.containerID = cc.ID
-	return dockerutil.StartContainer(ctx, r.client, r.containerID)
+	if err := dockerutil.StartContainer(ctx, r.client, r.containerID); err != nil {
+		return err
+	}
+
+	if r.metrics {
+		c, err := r.client.ContainerInspect(ctx, r.containerID)
+		if err != nil {
+			return fmt.Errorf("inspecting container: %w", err)
+		}
+		r.mu.Lock()
+		r.hostMetricsAddr = dockerutil.GetHostPort(c, metricsPort)
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+// containerName is the name of the container created by StartRelayer for pathNames.
+func (r *DockerRelayer) containerName(pathNames []string) string {
+	return fmt.Sprintf("%s-%s-%s", r.c.Name(), strings.Join(pathNames, "_"), r.instance)
 }
 
 func (r *DockerRelayer) stopContainer(ctx context.Context) error {
@@ -473,6 +664,115 @@ func (r *DockerRelayer) stopContainer(ctx context.Context) error {
 	return r.client.ContainerStop(ctx, r.containerID, &timeout)
 }
 
+// errRelayerNotStarted is returned when an operation requires the container created by StartRelayer.
+var errRelayerNotStarted = errors.New("relayer not started")
+
+// errMetricsNotEnabled is returned when metrics are requested from a relayer
+// constructed without the RelayerOptionMetrics option.
+var errMetricsNotEnabled = errors.New("relayer metrics not enabled")
+
+// MetricsURL returns the URL, reachable from the host, of the metrics endpoint
+// of the relayer started by StartRelayer.
+// The relayer must have been constructed with the EnableMetrics option.
+func (r *DockerRelayer) MetricsURL() (string, error) {
+	if !r.metrics {
+		return "", errMetricsNotEnabled
+	}
+	r.mu.Lock()
+	addr := r.hostMetricsAddr
+	r.mu.Unlock()
+	if addr == "" {
+		return "", errRelayerNotStarted
+	}
+	return "http://" + addr + r.c.(MetricsCommander).MetricsPath(), nil
+}
+
+// Metrics scrapes the metrics of the relayer started by StartRelayer.
+func (r *DockerRelayer) Metrics(ctx context.Context) (Metrics, error) {
+	url, err := r.MetricsURL()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scraping relayer metrics: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scraping relayer metrics: unexpected status %s", res.Status)
+	}
+	return ParseMetrics(res.Body)
+}
+
+// trackEfficiency scrapes the metrics of the relayer started by StartRelayer
+// and passes how much it relayed, and what it spent doing so, to rep.
+// It does nothing unless metrics are enabled, rep is an ibc.RelayerEfficiencyReporter,
+// and the relayer's commander is an EfficiencyCommander.
+func (r *DockerRelayer) trackEfficiency(ctx context.Context, rep ibc.RelayerExecReporter) {
+	er, ok := rep.(ibc.RelayerEfficiencyReporter)
+	if !ok || !r.metrics {
+		return
+	}
+	ec, ok := r.c.(EfficiencyCommander)
+	if !ok {
+		return
+	}
+
+	m, err := r.Metrics(ctx)
+	if err != nil {
+		r.log.Info("Failed to scrape relayer metrics", zap.Error(err))
+		return
+	}
+	c, err := r.client.ContainerInspect(ctx, r.containerID)
+	if err != nil {
+		r.log.Info("Failed to inspect relayer container", zap.Error(err))
+		return
+	}
+
+	packetsRelayed, feesSpent := ec.Efficiency(m)
+	er.TrackRelayerEfficiency(strings.TrimPrefix(c.Name, "/"), time.Now(), packetsRelayed, feesSpent)
+}
+
+// DisconnectNetwork partitions the container started by StartRelayer from the test network,
+// so that the relayer cannot reach any chain.
+func (r *DockerRelayer) DisconnectNetwork(ctx context.Context) error {
+	if r.containerID == "" {
+		return errRelayerNotStarted
+	}
+	return dockerutil.DisconnectNetwork(ctx, r.client, r.networkID, r.containerID)
+}
+
+// ReconnectNetwork heals a partition created by DisconnectNetwork.
+func (r *DockerRelayer) ReconnectNetwork(ctx context.Context) error {
+	if r.containerID == "" {
+		return errRelayerNotStarted
+	}
+	return dockerutil.ConnectNetwork(ctx, r.client, r.networkID, r.containerID)
+}
+
+// SetNetworkConditions degrades the outgoing network traffic of the container started by StartRelayer,
+// replacing any conditions previously set.
+func (r *DockerRelayer) SetNetworkConditions(ctx context.Context, cond ibc.NetworkConditions) error {
+	if r.containerID == "" {
+		return errRelayerNotStarted
+	}
+	return dockerutil.SetNetem(ctx, r.log, r.client, r.testName, r.containerID, cond.Latency, cond.Jitter, cond.PacketLoss)
+}
+
+// ClearNetworkConditions restores the relayer's network traffic after SetNetworkConditions.
+func (r *DockerRelayer) ClearNetworkConditions(ctx context.Context) error {
+	if r.containerID == "" {
+		return errRelayerNotStarted
+	}
+	return dockerutil.ClearNetem(ctx, r.log, r.client, r.testName, r.containerID)
+}
+
 func (r *DockerRelayer) Name() string {
 	return r.c.Name() + "-" + dockerutil.SanitizeContainerName(r.testName)
 }
@@ -608,6 +908,10 @@ type RelayerCommander interface {
 	// ConfigContent generates the content of the config file that will be passed to AddChainConfiguration.
 	ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error)
 
+	// PathConfigContent generates the content of the path file that will be passed to AddPath,
+	// describing a path over the existing clients and connection of src and dst.
+	PathConfigContent(src, dst ibc.PathEnd) ([]byte, error)
+
 	// ParseAddKeyOutput processes the output of AddKey
 	// to produce the wallet that was created.
 	ParseAddKeyOutput(stdout, stderr string) (ibc.RelayerWallet, error)
@@ -631,6 +935,8 @@ type RelayerCommander interface {
 
 	AddChainConfiguration(containerFilePath, homeDir string) []string
 	AddKey(chainID, keyName, homeDir string) []string
+	AddPath(srcChainID, dstChainID, pathName, containerFilePath, homeDir string) []string
+	CloseChannel(pathName, portID, channelID, homeDir string) []string
 	CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) []string
 	CreateClients(pathName, homeDir string) []string
 	CreateConnections(pathName, homeDir string) []string
@@ -641,6 +947,38 @@ type RelayerCommander interface {
 	GetConnections(chainID, homeDir string) []string
 	LinkPath(pathName, homeDir string, opts ibc.CreateChannelOptions) []string
 	RestoreKey(chainID, keyName, mnemonic, homeDir string) []string
-	StartRelayer(pathName, homeDir string) []string
+	StartRelayer(homeDir string, pathNames ...string) []string
 	UpdateClients(pathName, homeDir string) []string
 }
+
+// ReadinessCommander is implemented by a RelayerCommander whose relayer logs a recognizable line
+// once it has started relaying, either on all its chains at once or on each chain in turn.
+// The relayers of other commanders are considered ready as soon as they log any line.
+type ReadinessCommander interface {
+	// IsReadyLogLine reports whether line shows that the relayer has started relaying.
+	// If the line is about a single chain, chainID is that chain's ID,
+	// and the relayer is ready once such a line was logged for every chain of the started paths.
+	// Otherwise chainID is empty, and the relayer is ready on all its chains.
+	IsReadyLogLine(line LogLine) (ready bool, chainID string)
+}
+
+// MetricsCommander is implemented by a RelayerCommander whose relayer
+// serves Prometheus metrics while running, when constructed with the EnableMetrics option.
+type MetricsCommander interface {
+	// MetricsPort is the container port serving metrics, e.g. "5183/tcp".
+	MetricsPort() string
+
+	// MetricsPath is the HTTP path of the metrics on MetricsPort.
+	MetricsPath() string
+}
+
+// EfficiencyCommander is implemented by a MetricsCommander that can tell from its relayer's metrics
+// how much the relayer relayed and what it spent doing so,
+// which StopRelayer passes to a reporter that is an ibc.RelayerEfficiencyReporter.
+type EfficiencyCommander interface {
+	MetricsCommander
+
+	// Efficiency returns the number of packet messages the relayer submitted,
+	// and the fees its wallets paid, keyed by denom.
+	Efficiency(m Metrics) (packetsRelayed uint64, feesSpent map[string]float64)
+}
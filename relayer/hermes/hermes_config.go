@@ -0,0 +1,173 @@
+package hermes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/pelletier/go-toml"
+	"github.com/strangelove-ventures/ibctest/ibc"
+)
+
+// GlobalConfig is the portion of the Hermes config.toml that is not specific to any chain.
+// It is written once, when the relayer is initialized.
+type GlobalConfig struct {
+	Global    GlobalSection  `toml:"global"`
+	Mode      ModeSection    `toml:"mode"`
+	REST      ServiceSection `toml:"rest"`
+	Telemetry ServiceSection `toml:"telemetry"`
+}
+
+type GlobalSection struct {
+	LogLevel string `toml:"log_level"`
+}
+
+type ModeSection struct {
+	Clients     ClientsMode `toml:"clients"`
+	Connections EnabledMode `toml:"connections"`
+	Channels    EnabledMode `toml:"channels"`
+	Packets     PacketsMode `toml:"packets"`
+}
+
+type ClientsMode struct {
+	Enabled      bool `toml:"enabled"`
+	Refresh      bool `toml:"refresh"`
+	Misbehaviour bool `toml:"misbehaviour"`
+}
+
+type EnabledMode struct {
+	Enabled bool `toml:"enabled"`
+}
+
+type PacketsMode struct {
+	Enabled        bool `toml:"enabled"`
+	ClearInterval  int  `toml:"clear_interval"`
+	ClearOnStart   bool `toml:"clear_on_start"`
+	TxConfirmation bool `toml:"tx_confirmation"`
+}
+
+type ServiceSection struct {
+	Enabled bool   `toml:"enabled"`
+	Host    string `toml:"host"`
+	Port    int    `toml:"port"`
+}
+
+// ChainConfig is a single entry in the [[chains]] array of the Hermes config.toml.
+type ChainConfig struct {
+	ID             string         `toml:"id"`
+	RPCAddr        string         `toml:"rpc_addr"`
+	GRPCAddr       string         `toml:"grpc_addr"`
+	WebsocketAddr  string         `toml:"websocket_addr"`
+	RPCTimeout     string         `toml:"rpc_timeout"`
+	AccountPrefix  string         `toml:"account_prefix"`
+	KeyName        string         `toml:"key_name"`
+	StorePrefix    string         `toml:"store_prefix"`
+	DefaultGas     int            `toml:"default_gas"`
+	MaxGas         int            `toml:"max_gas"`
+	GasPrice       GasPrice       `toml:"gas_price"`
+	GasMultiplier  float64        `toml:"gas_multiplier"`
+	MaxMsgNum      int            `toml:"max_msg_num"`
+	MaxTxSize      int            `toml:"max_tx_size"`
+	ClockDrift     string         `toml:"clock_drift"`
+	MaxBlockTime   string         `toml:"max_block_time"`
+	TrustingPeriod string         `toml:"trusting_period"`
+	TrustThreshold TrustThreshold `toml:"trust_threshold"`
+	AddressType    AddressType    `toml:"address_type"`
+}
+
+type GasPrice struct {
+	Price float64 `toml:"price"`
+	Denom string  `toml:"denom"`
+}
+
+type TrustThreshold struct {
+	Numerator   string `toml:"numerator"`
+	Denominator string `toml:"denominator"`
+}
+
+type AddressType struct {
+	Derivation string `toml:"derivation"`
+}
+
+// DefaultGlobalConfig returns the global configuration used by ibctest.
+// Hermes is only responsible for clients and packets while running;
+// connection and channel creation are driven explicitly by the tests.
+func DefaultGlobalConfig() GlobalConfig {
+	return GlobalConfig{
+		Global: GlobalSection{LogLevel: "info"},
+		Mode: ModeSection{
+			Clients: ClientsMode{
+				Enabled:      true,
+				Refresh:      true,
+				Misbehaviour: true,
+			},
+			Connections: EnabledMode{Enabled: false},
+			Channels:    EnabledMode{Enabled: false},
+			Packets: PacketsMode{
+				Enabled:        true,
+				ClearInterval:  100,
+				ClearOnStart:   true,
+				TxConfirmation: false,
+			},
+		},
+		REST:      ServiceSection{Enabled: false, Host: "0.0.0.0", Port: 3000},
+		Telemetry: ServiceSection{Enabled: false, Host: "0.0.0.0", Port: 3001},
+	}
+}
+
+// ChainConfigToHermesChainConfig converts the ibctest chain configuration
+// into the equivalent Hermes [[chains]] entry.
+func ChainConfigToHermesChainConfig(chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) (ChainConfig, error) {
+	gasPrice, err := types.ParseDecCoin(chainConfig.GasPrices)
+	if err != nil {
+		return ChainConfig{}, fmt.Errorf("parsing gas prices %q: %w", chainConfig.GasPrices, err)
+	}
+	price, err := gasPrice.Amount.Float64()
+	if err != nil {
+		return ChainConfig{}, fmt.Errorf("converting gas price %s: %w", gasPrice.Amount, err)
+	}
+
+	gasMultiplier := chainConfig.GasAdjustment
+	if gasMultiplier == 0 {
+		gasMultiplier = 1.1
+	}
+
+	trustingPeriod := chainConfig.TrustingPeriod
+	if trustingPeriod == "" {
+		trustingPeriod = "14days"
+	}
+
+	return ChainConfig{
+		ID:      chainConfig.ChainID,
+		RPCAddr: rpcAddr,
+		// Hermes requires a scheme on the gRPC address, whereas the chains report a bare host:port.
+		GRPCAddr:       "http://" + strings.TrimPrefix(grpcAddr, "http://"),
+		WebsocketAddr:  strings.Replace(rpcAddr, "http://", "ws://", 1) + "/websocket",
+		RPCTimeout:     "10s",
+		AccountPrefix:  chainConfig.Bech32Prefix,
+		KeyName:        keyName,
+		StorePrefix:    "ibc",
+		DefaultGas:     100_000,
+		MaxGas:         3_000_000,
+		GasPrice:       GasPrice{Price: price, Denom: gasPrice.Denom},
+		GasMultiplier:  gasMultiplier,
+		MaxMsgNum:      30,
+		MaxTxSize:      2_097_152,
+		ClockDrift:     "5s",
+		MaxBlockTime:   "30s",
+		TrustingPeriod: trustingPeriod,
+		TrustThreshold: TrustThreshold{Numerator: "1", Denominator: "3"},
+		AddressType:    AddressType{Derivation: "cosmos"},
+	}, nil
+}
+
+// chainsFragment wraps a single chain so that it is encoded as one [[chains]] entry.
+// Fragments can be appended to a config.toml containing the global configuration
+// and any previously added chains.
+type chainsFragment struct {
+	Chains []ChainConfig `toml:"chains"`
+}
+
+func marshalChainConfig(c ChainConfig) ([]byte, error) {
+	return toml.Marshal(chainsFragment{Chains: []ChainConfig{c}})
+}
@@ -0,0 +1,811 @@
+// Package hermes provides an interface to the Hermes relayer running in a Docker container.
+package hermes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/cosmos/go-bip39"
+	conntypes "github.com/cosmos/ibc-go/v4/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	"github.com/docker/docker/client"
+	"github.com/pelletier/go-toml"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultContainerImage   = "ghcr.io/informalsystems/hermes"
+	DefaultContainerVersion = "v1.0.0"
+)
+
+// Capabilities returns the set of capabilities of the Hermes relayer.
+func Capabilities() map[relayer.Capability]bool {
+	// Flushing is implemented with the "tx packet-recv" and "tx packet-ack" commands,
+	// and misbehaviour detection is enabled in the global config.
+	c := relayer.FullCapabilities()
+
+	// Closing a channel requires the channel's connection on both ends,
+	// which HermesRelayer only tracks for the channels it creates.
+	c[relayer.CloseChannel] = false
+
+	return c
+}
+
+// HermesRelayer is the ibc.Relayer implementation for github.com/informalsystems/ibc-rs.
+//
+// Hermes has no concept of a named path, and most of its transaction commands
+// require client, connection, or channel identifiers rather than a path name.
+// HermesRelayer therefore tracks the identifiers it creates for each path,
+// and overrides the corresponding methods of the embedded DockerRelayer.
+type HermesRelayer struct {
+	// Embedded DockerRelayer so commands just work.
+	*relayer.DockerRelayer
+
+	c commander
+
+	mu    sync.Mutex
+	paths map[string]*pathConfig
+}
+
+// pathConfig holds the identifiers Hermes created or discovered for a single path.
+type pathConfig struct {
+	chainA, chainB pathEnd
+
+	channels []channelPair
+}
+
+type pathEnd struct {
+	chainID      string
+	clientID     string
+	connectionID string
+}
+
+type channelPair struct {
+	portA, channelA string
+	portB, channelB string
+}
+
+func NewHermesRelayer(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...relayer.RelayerOption) *HermesRelayer {
+	c := commander{log: log}
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case relayer.RelayerOptionExtraStartFlags:
+			c.extraStartFlags = o.Flags
+		case relayer.RelayerOptionMetrics:
+			c.telemetry = true
+		case relayer.RelayerOptionChannelHandshakes:
+			c.channelHandshakes = true
+		}
+	}
+	dr, err := relayer.NewDockerRelayer(context.TODO(), log, testName, cli, networkID, c, options...)
+	if err != nil {
+		panic(err) // TODO: return
+	}
+
+	return &HermesRelayer{
+		DockerRelayer: dr,
+		c:             c,
+		paths:         map[string]*pathConfig{},
+	}
+}
+
+// GeneratePath records the chains on either end of pathName,
+// and validates that the Hermes configuration contains both chains.
+func (r *HermesRelayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
+	if err := r.DockerRelayer.GeneratePath(ctx, rep, srcChainID, dstChainID, pathName); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths[pathName] = &pathConfig{
+		chainA: pathEnd{chainID: srcChainID},
+		chainB: pathEnd{chainID: dstChainID},
+	}
+	return nil
+}
+
+// LinkPath creates clients, a connection, and a channel for pathName.
+func (r *HermesRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
+	if err := r.CreateClients(ctx, rep, pathName); err != nil {
+		return err
+	}
+	if err := r.CreateConnections(ctx, rep, pathName); err != nil {
+		return err
+	}
+	return r.CreateChannel(ctx, rep, pathName, opts)
+}
+
+// LinkExistingPath records the existing clients and connection of src and dst for pathName,
+// along with the channels already open on that connection.
+func (r *HermesRelayer) LinkExistingPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, src, dst ibc.PathEnd) error {
+	if err := r.GeneratePath(ctx, rep, src.ChainID, dst.ChainID, pathName); err != nil {
+		return err
+	}
+
+	channels, err := r.GetChannels(ctx, rep, src.ChainID)
+	if err != nil {
+		return err
+	}
+
+	p := &pathConfig{
+		chainA: pathEnd{chainID: src.ChainID, clientID: src.ClientID, connectionID: src.ConnectionID},
+		chainB: pathEnd{chainID: dst.ChainID, clientID: dst.ClientID, connectionID: dst.ConnectionID},
+	}
+	for _, ch := range channels {
+		if len(ch.ConnectionHops) == 0 || ch.ConnectionHops[0] != src.ConnectionID {
+			continue
+		}
+		p.channels = append(p.channels, channelPair{
+			portA:    ch.PortID,
+			channelA: ch.ChannelID,
+			portB:    ch.Counterparty.PortID,
+			channelB: ch.Counterparty.ChannelID,
+		})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths[pathName] = p
+	return nil
+}
+
+func (r *HermesRelayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+
+	clientA, err := r.createClient(ctx, rep, p.chainA.chainID, p.chainB.chainID)
+	if err != nil {
+		return err
+	}
+	clientB, err := r.createClient(ctx, rep, p.chainB.chainID, p.chainA.chainID)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p.chainA.clientID = clientA
+	p.chainB.clientID = clientB
+	return nil
+}
+
+func (r *HermesRelayer) createClient(ctx context.Context, rep ibc.RelayerExecReporter, hostChainID, referenceChainID string) (string, error) {
+	cmd := r.c.hermes(r.NodeHome(),
+		"create", "client",
+		"--host-chain", hostChainID,
+		"--reference-chain", referenceChainID,
+	)
+	res := r.Exec(ctx, rep, cmd, nil)
+	if res.Err != nil {
+		return "", res.Err
+	}
+
+	var out struct {
+		CreateClient struct {
+			ClientID string `json:"client_id"`
+		}
+	}
+	if err := parseResult(string(res.Stdout), &out); err != nil {
+		return "", fmt.Errorf("parsing create client output: %w", err)
+	}
+	if out.CreateClient.ClientID == "" {
+		return "", fmt.Errorf("no client ID in create client output: %s", res.Stdout)
+	}
+	return out.CreateClient.ClientID, nil
+}
+
+func (r *HermesRelayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.chainA.clientID == "" || p.chainB.clientID == "" {
+		return fmt.Errorf("clients for path %q have not been created", pathName)
+	}
+
+	cmd := r.c.hermes(r.NodeHome(),
+		"create", "connection",
+		"--a-chain", p.chainA.chainID,
+		"--a-client", p.chainA.clientID,
+		"--b-client", p.chainB.clientID,
+	)
+	res := r.Exec(ctx, rep, cmd, nil)
+	if res.Err != nil {
+		return res.Err
+	}
+
+	var out struct {
+		ASide struct {
+			ConnectionID string `json:"connection_id"`
+		} `json:"a_side"`
+		BSide struct {
+			ConnectionID string `json:"connection_id"`
+		} `json:"b_side"`
+	}
+	if err := parseResult(string(res.Stdout), &out); err != nil {
+		return fmt.Errorf("parsing create connection output: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p.chainA.connectionID = out.ASide.ConnectionID
+	p.chainB.connectionID = out.BSide.ConnectionID
+	return nil
+}
+
+func (r *HermesRelayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.chainA.connectionID == "" {
+		return fmt.Errorf("connection for path %q has not been created", pathName)
+	}
+
+	cmd := r.c.hermes(r.NodeHome(),
+		"create", "channel",
+		"--a-chain", p.chainA.chainID,
+		"--a-connection", p.chainA.connectionID,
+		"--a-port", opts.SourcePortName,
+		"--b-port", opts.DestPortName,
+		"--order", opts.Order.String(),
+		"--channel-version", opts.Version,
+	)
+	res := r.Exec(ctx, rep, cmd, nil)
+	if res.Err != nil {
+		return res.Err
+	}
+
+	var out struct {
+		ASide struct {
+			ChannelID string `json:"channel_id"`
+		} `json:"a_side"`
+		BSide struct {
+			ChannelID string `json:"channel_id"`
+		} `json:"b_side"`
+	}
+	if err := parseResult(string(res.Stdout), &out); err != nil {
+		return fmt.Errorf("parsing create channel output: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p.channels = append(p.channels, channelPair{
+		portA:    opts.SourcePortName,
+		channelA: out.ASide.ChannelID,
+		portB:    opts.DestPortName,
+		channelB: out.BSide.ChannelID,
+	})
+	return nil
+}
+
+func (r *HermesRelayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range []pathEnd{p.chainA, p.chainB} {
+		if e.clientID == "" {
+			return fmt.Errorf("clients for path %q have not been created", pathName)
+		}
+		cmd := r.c.hermes(r.NodeHome(),
+			"update", "client",
+			"--host-chain", e.chainID,
+			"--client", e.clientID,
+		)
+		if res := r.Exec(ctx, rep, cmd, nil); res.Err != nil {
+			return res.Err
+		}
+	}
+	return nil
+}
+
+// FlushPackets relays any pending packets, in both directions,
+// on the channel identified by channelID on either end of pathName.
+func (r *HermesRelayer) FlushPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
+	return r.flush(ctx, rep, pathName, channelID, "packet-recv")
+}
+
+// FlushAcknowledgements relays any pending acknowledgements, in both directions,
+// on the channel identified by channelID on either end of pathName.
+func (r *HermesRelayer) FlushAcknowledgements(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
+	return r.flush(ctx, rep, pathName, channelID, "packet-ack")
+}
+
+func (r *HermesRelayer) flush(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID, txCmd string) error {
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+
+	for _, ch := range p.channels {
+		if ch.channelA != channelID && ch.channelB != channelID {
+			continue
+		}
+
+		directions := []struct {
+			srcChain, dstChain string
+			srcPort, srcChan   string
+		}{
+			{p.chainA.chainID, p.chainB.chainID, ch.portA, ch.channelA},
+			{p.chainB.chainID, p.chainA.chainID, ch.portB, ch.channelB},
+		}
+		for _, d := range directions {
+			cmd := r.c.hermes(r.NodeHome(),
+				"tx", txCmd,
+				"--dst-chain", d.dstChain,
+				"--src-chain", d.srcChain,
+				"--src-port", d.srcPort,
+				"--src-channel", d.srcChan,
+			)
+			if res := r.Exec(ctx, rep, cmd, nil); res.Err != nil {
+				return res.Err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("channel %q not found on path %q", channelID, pathName)
+}
+
+// CloseChannel is not supported by HermesRelayer; see Capabilities.
+func (r *HermesRelayer) CloseChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, portID, channelID string) error {
+	return fmt.Errorf("closing channel %s/%s on path %q is not supported by hermes relayer", portID, channelID, pathName)
+}
+
+// GetChannels returns the channels on chainID,
+// including the channel end details that Hermes only reports through a separate query.
+func (r *HermesRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
+	channels, err := r.DockerRelayer.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, ch := range channels {
+		cmd := r.c.hermes(r.NodeHome(),
+			"query", "channel", "end",
+			"--chain", chainID,
+			"--port", ch.PortID,
+			"--channel", ch.ChannelID,
+		)
+		res := r.Exec(ctx, rep, cmd, nil)
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		var end hermesChannelEnd
+		if err := parseResult(string(res.Stdout), &end); err != nil {
+			return nil, fmt.Errorf("parsing channel end output: %w", err)
+		}
+
+		channels[i].State = channelState(end.State)
+		channels[i].Ordering = channelOrder(end.Ordering)
+		channels[i].Counterparty = ibc.ChannelCounterparty{
+			PortID:    end.Remote.PortID,
+			ChannelID: end.Remote.ChannelID,
+		}
+		channels[i].ConnectionHops = end.ConnectionHops
+		channels[i].Version = end.Version
+	}
+
+	return channels, nil
+}
+
+// GetConnections returns the connections on chainID,
+// including the connection end details that Hermes only reports through a separate query.
+func (r *HermesRelayer) GetConnections(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (ibc.ConnectionOutputs, error) {
+	connections, err := r.DockerRelayer.GetConnections(ctx, rep, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, conn := range connections {
+		cmd := r.c.hermes(r.NodeHome(),
+			"query", "connection", "end",
+			"--chain", chainID,
+			"--connection", conn.ID,
+		)
+		res := r.Exec(ctx, rep, cmd, nil)
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		var end hermesConnectionEnd
+		if err := parseResult(string(res.Stdout), &end); err != nil {
+			return nil, fmt.Errorf("parsing connection end output: %w", err)
+		}
+
+		conn.ClientID = end.ClientID
+		conn.State = connectionState(end.State)
+		conn.Counterparty = &conntypes.Counterparty{
+			ClientId:     end.Counterparty.ClientID,
+			ConnectionId: end.Counterparty.ConnectionID,
+		}
+		for _, v := range end.Versions {
+			conn.Versions = append(conn.Versions, &conntypes.Version{
+				Identifier: v.Identifier,
+				Features:   v.Features,
+			})
+		}
+	}
+
+	return connections, nil
+}
+
+func (r *HermesRelayer) path(pathName string) (*pathConfig, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.paths[pathName]
+	if !ok {
+		return nil, fmt.Errorf("path %q not found; GeneratePath must be called first", pathName)
+	}
+	return p, nil
+}
+
+// commander satisfies relayer.RelayerCommander.
+//
+// Hermes identifies clients, connections, and channels explicitly rather than by path,
+// so the commands that operate on an existing path are issued by HermesRelayer instead.
+type commander struct {
+	log             *zap.Logger
+	extraStartFlags []string
+	telemetry       bool
+
+	// channelHandshakes enables the channel mode of Hermes,
+	// so that it completes channel handshakes started by applications while running.
+	channelHandshakes bool
+}
+
+var (
+	_ relayer.MetricsCommander   = commander{}
+	_ relayer.ReadinessCommander = commander{}
+)
+
+// errPathCommand is the panic value for path-based commands that HermesRelayer overrides.
+var errPathCommand = errors.New("hermes path commands must be issued through *HermesRelayer")
+
+// hermes returns a hermes command using the config file in homeDir and JSON output.
+func (commander) hermes(homeDir string, args ...string) []string {
+	return append([]string{
+		"hermes", "--config", configPath(homeDir), "--json",
+	}, args...)
+}
+
+func configPath(homeDir string) string {
+	return path.Join(homeDir, "config.toml")
+}
+
+func (commander) Name() string {
+	return "hermes"
+}
+
+func (commander) DockerUser() string {
+	return "hermes" // The name of the user according to the Hermes Dockerfile.
+}
+
+// MetricsPort is the port of the Hermes telemetry service, which serves Prometheus metrics.
+func (commander) MetricsPort() string {
+	return fmt.Sprintf("%d/tcp", DefaultGlobalConfig().Telemetry.Port)
+}
+
+func (commander) MetricsPath() string {
+	return "/metrics"
+}
+
+// IsReadyLogLine reports whether line is logged by Hermes once it has spawned the workers relaying its chains,
+// which it does once for all its chains.
+func (commander) IsReadyLogLine(line relayer.LogLine) (bool, string) {
+	return line.Message == "Hermes has started", ""
+}
+
+func (commander) DefaultContainerImage() string {
+	return DefaultContainerImage
+}
+
+func (commander) DefaultContainerVersion() string {
+	return DefaultContainerVersion
+}
+
+// Init writes the global configuration.
+// Chains are appended to the same file by AddChainConfiguration.
+func (c commander) Init(homeDir string) []string {
+	cfg := DefaultGlobalConfig()
+	cfg.Telemetry.Enabled = c.telemetry
+	cfg.Mode.Channels.Enabled = c.channelHandshakes
+	content, err := toml.Marshal(cfg)
+	if err != nil {
+		// The global config is a fixed structure, so this can only be a programming error.
+		panic(fmt.Errorf("marshaling hermes global config: %w", err))
+	}
+	return []string{
+		"sh", "-c", `mkdir -p "$1" && printf '%s\n' "$2" > "$3"`,
+		"_", homeDir, string(content), configPath(homeDir),
+	}
+}
+
+func (commander) ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error) {
+	hermesChainConfig, err := ChainConfigToHermesChainConfig(cfg, keyName, rpcAddr, grpcAddr)
+	if err != nil {
+		return nil, err
+	}
+	return marshalChainConfig(hermesChainConfig)
+}
+
+func (commander) PathConfigContent(src, dst ibc.PathEnd) ([]byte, error) {
+	panic(errPathCommand)
+}
+
+func (commander) AddChainConfiguration(containerFilePath, homeDir string) []string {
+	return []string{
+		"sh", "-c", `cat "$1" >> "$2"`,
+		"_", containerFilePath, configPath(homeDir),
+	}
+}
+
+// AddKey generates a new mnemonic and restores it into the keyring,
+// as Hermes does not generate keys itself.
+// The mnemonic is printed on the final line of output so that ParseAddKeyOutput can report it.
+func (c commander) AddKey(chainID, keyName, homeDir string) []string {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		panic(fmt.Errorf("generating entropy for mnemonic: %w", err))
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		panic(fmt.Errorf("generating mnemonic: %w", err))
+	}
+
+	cmd := c.RestoreKey(chainID, keyName, mnemonic, homeDir)
+	// Extend the restore script to echo the mnemonic after a successful restore.
+	cmd[2] += ` && printf '%s\n' "$1"`
+	return cmd
+}
+
+func (c commander) RestoreKey(chainID, keyName, mnemonic, homeDir string) []string {
+	mnemonicPath := path.Join(homeDir, chainID+".mnemonic")
+	restore := strings.Join(c.hermes(homeDir,
+		"keys", "add",
+		"--chain", `"$3"`,
+		"--key-name", `"$4"`,
+		"--mnemonic-file", `"$2"`,
+		"--overwrite",
+	), " ")
+	return []string{
+		"sh", "-c", `printf '%s' "$1" > "$2" && ` + restore,
+		"_", mnemonic, mnemonicPath, chainID, keyName,
+	}
+}
+
+func (c commander) GeneratePath(srcChainID, dstChainID, pathName, homeDir string) []string {
+	// Hermes has no path configuration; just confirm that the configuration is sound.
+	return c.hermes(homeDir, "config", "validate")
+}
+
+func (c commander) GetChannels(chainID, homeDir string) []string {
+	return c.hermes(homeDir, "query", "channels", "--chain", chainID)
+}
+
+func (c commander) GetConnections(chainID, homeDir string) []string {
+	return c.hermes(homeDir, "query", "connections", "--chain", chainID)
+}
+
+// StartRelayer starts Hermes, which relays every configured chain regardless of pathNames.
+func (c commander) StartRelayer(homeDir string, pathNames ...string) []string {
+	cmd := c.hermes(homeDir, "start")
+	cmd = append(cmd, c.extraStartFlags...)
+	return cmd
+}
+
+func (commander) CloseChannel(pathName, portID, channelID, homeDir string) []string {
+	panic(errPathCommand)
+}
+
+func (commander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) []string {
+	panic(errPathCommand)
+}
+
+func (commander) CreateClients(pathName, homeDir string) []string {
+	panic(errPathCommand)
+}
+
+func (commander) CreateConnections(pathName, homeDir string) []string {
+	panic(errPathCommand)
+}
+
+func (commander) AddPath(srcChainID, dstChainID, pathName, containerFilePath, homeDir string) []string {
+	panic(errPathCommand)
+}
+
+func (commander) FlushAcknowledgements(pathName, channelID, homeDir string) []string {
+	panic(errPathCommand)
+}
+
+func (commander) FlushPackets(pathName, channelID, homeDir string) []string {
+	panic(errPathCommand)
+}
+
+func (commander) LinkPath(pathName, homeDir string, opts ibc.CreateChannelOptions) []string {
+	panic(errPathCommand)
+}
+
+func (commander) UpdateClients(pathName, homeDir string) []string {
+	panic(errPathCommand)
+}
+
+// keyAddressRe matches the address in the result of "hermes keys add",
+// e.g. "Restored key 'gaia' (cosmos1...) on chain gaia-1".
+var keyAddressRe = regexp.MustCompile(`\(([a-z0-9]+1[a-z0-9]+)\)`)
+
+func (commander) ParseAddKeyOutput(stdout, stderr string) (ibc.RelayerWallet, error) {
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) < 2 {
+		return ibc.RelayerWallet{}, fmt.Errorf("unexpected add key output: %s", stdout)
+	}
+
+	var result string
+	if err := parseResult(strings.Join(lines[:len(lines)-1], "\n"), &result); err != nil {
+		return ibc.RelayerWallet{}, fmt.Errorf("parsing add key output: %w", err)
+	}
+	m := keyAddressRe.FindStringSubmatch(result)
+	if m == nil {
+		return ibc.RelayerWallet{}, fmt.Errorf("no address in add key output: %s", result)
+	}
+
+	return ibc.RelayerWallet{
+		Mnemonic: strings.TrimSpace(lines[len(lines)-1]),
+		Address:  m[1],
+	}, nil
+}
+
+func (c commander) ParseRestoreKeyOutput(stdout, stderr string) string {
+	var result string
+	if err := parseResult(stdout, &result); err != nil {
+		c.log.Error("Failed to parse restore key output", zap.Error(err))
+		return ""
+	}
+	m := keyAddressRe.FindStringSubmatch(result)
+	if m == nil {
+		c.log.Error("No address in restore key output", zap.String("output", result))
+		return ""
+	}
+	return m[1]
+}
+
+// ParseGetChannelsOutput parses the list of port and channel identifiers returned by "hermes query channels".
+// The remaining fields are filled in by HermesRelayer.GetChannels.
+func (commander) ParseGetChannelsOutput(stdout, stderr string) ([]ibc.ChannelOutput, error) {
+	var ids []struct {
+		PortID    string `json:"port_id"`
+		ChannelID string `json:"channel_id"`
+	}
+	if err := parseResult(stdout, &ids); err != nil {
+		return nil, fmt.Errorf("parsing channels output: %w", err)
+	}
+
+	channels := make([]ibc.ChannelOutput, len(ids))
+	for i, id := range ids {
+		channels[i] = ibc.ChannelOutput{
+			PortID:    id.PortID,
+			ChannelID: id.ChannelID,
+		}
+	}
+	return channels, nil
+}
+
+// ParseGetConnectionsOutput parses the list of connection identifiers returned by "hermes query connections".
+// The remaining fields are filled in by HermesRelayer.GetConnections.
+func (commander) ParseGetConnectionsOutput(stdout, stderr string) (ibc.ConnectionOutputs, error) {
+	var ids []string
+	if err := parseResult(stdout, &ids); err != nil {
+		return nil, fmt.Errorf("parsing connections output: %w", err)
+	}
+
+	connections := make(ibc.ConnectionOutputs, len(ids))
+	for i, id := range ids {
+		connections[i] = &ibc.ConnectionOutput{ID: id}
+	}
+	return connections, nil
+}
+
+// hermesChannelEnd is the result of "hermes query channel end".
+type hermesChannelEnd struct {
+	State          string   `json:"state"`
+	Ordering       string   `json:"ordering"`
+	ConnectionHops []string `json:"connection_hops"`
+	Version        string   `json:"version"`
+	Remote         struct {
+		PortID    string `json:"port_id"`
+		ChannelID string `json:"channel_id"`
+	} `json:"remote"`
+}
+
+// hermesConnectionEnd is the result of "hermes query connection end".
+type hermesConnectionEnd struct {
+	ClientID     string `json:"client_id"`
+	State        string `json:"state"`
+	Counterparty struct {
+		ClientID     string `json:"client_id"`
+		ConnectionID string `json:"connection_id"`
+	} `json:"counterparty"`
+	Versions []struct {
+		Identifier string   `json:"identifier"`
+		Features   []string `json:"features"`
+	} `json:"versions"`
+}
+
+// parseResult decodes the "result" field of the last JSON status line in stdout.
+// With the --json flag, Hermes writes one such line after any log output.
+func parseResult(stdout string, v any) error {
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		var out struct {
+			Status string          `json:"status"`
+			Result json.RawMessage `json:"result"`
+		}
+		if err := json.Unmarshal([]byte(lines[i]), &out); err != nil || out.Status == "" {
+			continue
+		}
+		if out.Status != "success" {
+			return fmt.Errorf("hermes reported status %q: %s", out.Status, out.Result)
+		}
+		return json.Unmarshal(out.Result, v)
+	}
+	return fmt.Errorf("no result found in output: %s", stdout)
+}
+
+// channelState converts a Hermes channel state, e.g. "Open",
+// to the string used by ibc-go, e.g. "STATE_OPEN".
+func channelState(s string) string {
+	switch s {
+	case "Init":
+		return chantypes.INIT.String()
+	case "TryOpen":
+		return chantypes.TRYOPEN.String()
+	case "Open":
+		return chantypes.OPEN.String()
+	case "Closed":
+		return chantypes.CLOSED.String()
+	default:
+		return chantypes.UNINITIALIZED.String()
+	}
+}
+
+// channelOrder converts a Hermes channel ordering, e.g. "Unordered",
+// to the string used by ibc-go, e.g. "ORDER_UNORDERED".
+func channelOrder(o string) string {
+	switch o {
+	case "Ordered":
+		return chantypes.ORDERED.String()
+	case "Unordered":
+		return chantypes.UNORDERED.String()
+	default:
+		return chantypes.NONE.String()
+	}
+}
+
+// connectionState converts a Hermes connection state, e.g. "Open",
+// to the string used by ibc-go, e.g. "STATE_OPEN".
+func connectionState(s string) string {
+	switch s {
+	case "Init":
+		return conntypes.INIT.String()
+	case "TryOpen":
+		return conntypes.TRYOPEN.String()
+	case "Open":
+		return conntypes.OPEN.String()
+	default:
+		return conntypes.UNINITIALIZED.String()
+	}
+}
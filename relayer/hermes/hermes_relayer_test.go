@@ -0,0 +1,85 @@
+package hermes
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseResult(t *testing.T) {
+	t.Run("skips log lines", func(t *testing.T) {
+		const stdout = `{"timestamp":"...","level":"INFO","fields":{"message":"using default configuration"}}
+{"result":["connection-0","connection-1"],"status":"success"}
+`
+		var ids []string
+		require.NoError(t, parseResult(stdout, &ids))
+		require.Equal(t, []string{"connection-0", "connection-1"}, ids)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		const stdout = `{"result":"chain not found","status":"error"}`
+		var s string
+		require.ErrorContains(t, parseResult(stdout, &s), "chain not found")
+	})
+
+	t.Run("no result", func(t *testing.T) {
+		var s string
+		require.Error(t, parseResult("", &s))
+	})
+}
+
+func TestCommander_ParseKeyOutput(t *testing.T) {
+	c := commander{log: zap.NewNop()}
+
+	const result = `{"result":"Restored key 'gaia' (cosmos1qyfkm2y3k7g4wd5jh0cm2f8ml9lqnzrhrvu7g6) on chain gaia-1","status":"success"}`
+
+	require.Equal(t, "cosmos1qyfkm2y3k7g4wd5jh0cm2f8ml9lqnzrhrvu7g6", c.ParseRestoreKeyOutput(result+"\n", ""))
+
+	wallet, err := c.ParseAddKeyOutput(result+"\nabandon ability able\n", "")
+	require.NoError(t, err)
+	require.Equal(t, ibc.RelayerWallet{
+		Mnemonic: "abandon ability able",
+		Address:  "cosmos1qyfkm2y3k7g4wd5jh0cm2f8ml9lqnzrhrvu7g6",
+	}, wallet)
+}
+
+func TestCommander_ParseGetChannelsOutput(t *testing.T) {
+	const stdout = `{"result":[{"channel_id":"channel-0","port_id":"transfer"}],"status":"success"}`
+
+	channels, err := commander{}.ParseGetChannelsOutput(stdout, "")
+	require.NoError(t, err)
+	require.Equal(t, []ibc.ChannelOutput{{PortID: "transfer", ChannelID: "channel-0"}}, channels)
+}
+
+func TestChannelState(t *testing.T) {
+	require.Equal(t, "STATE_OPEN", channelState("Open"))
+	require.Equal(t, "ORDER_UNORDERED", channelOrder("Unordered"))
+	require.Equal(t, "STATE_OPEN", connectionState("Open"))
+}
+
+func TestCommander_IsReadyLogLine(t *testing.T) {
+	c := commander{}
+	ready, chainID := c.IsReadyLogLine(relayer.ParseLogLine(`{"timestamp":"2022-08-01T10:00:00.000000Z","level":"INFO","fields":{"message":"Hermes has started"}}`))
+	require.True(t, ready)
+	// Hermes is ready on all its chains at once.
+	require.Empty(t, chainID)
+
+	ready, _ = c.IsReadyLogLine(relayer.ParseLogLine(`{"timestamp":"2022-08-01T10:00:00.000000Z","level":"INFO","fields":{"message":"spawning worker"}}`))
+	require.False(t, ready)
+}
+
+func TestCommander_InitChannelMode(t *testing.T) {
+	globalConfig := func(c commander) GlobalConfig {
+		cmd := c.Init("/home/hermes")
+		var cfg GlobalConfig
+		require.NoError(t, toml.Unmarshal([]byte(cmd[5]), &cfg))
+		return cfg
+	}
+
+	require.False(t, globalConfig(commander{}).Mode.Channels.Enabled)
+	require.True(t, globalConfig(commander{channelHandshakes: true}).Mode.Channels.Enabled)
+}
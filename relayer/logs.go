@@ -0,0 +1,279 @@
+package relayer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"go.uber.org/zap"
+)
+
+// LogLine is a parsed line of a relayer's log.
+type LogLine struct {
+	// When the line was logged, or the zero time if the line has no timestamp.
+	Time time.Time
+
+	// Level is the lowercase log level, e.g. "info".
+	// Level is empty, and Message is the whole line, if the line's format was not recognized.
+	Level   string
+	Message string
+
+	// Structured fields logged with the message.
+	Fields map[string]string
+}
+
+// LogSink receives the lines logged by the relayer container started by StartRelayer.
+type LogSink func(containerName string, line LogLine)
+
+// ParseLogLine parses a line logged by a relayer.
+// It recognizes the console and JSON formats of zap, including the JSON format of rly,
+// and the JSON format of tracing, used by Hermes.
+func ParseLogLine(line string) LogLine {
+	line = strings.TrimRight(line, "\r\n")
+	if strings.HasPrefix(line, "{") {
+		if l, ok := parseJSONLogLine(line); ok {
+			return l
+		}
+	}
+	if l, ok := parseConsoleLogLine(line); ok {
+		return l
+	}
+	return LogLine{Message: line}
+}
+
+// parseJSONLogLine parses a JSON object with a level in "level" or "lvl", a message in "msg" or "message",
+// and a timestamp in "ts" or "timestamp", treating any other keys as fields.
+// The message and fields may be nested in a "fields" object, as Hermes does.
+func parseJSONLogLine(line string) (LogLine, bool) {
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(line), &raw); err != nil {
+		return LogLine{}, false
+	}
+
+	// Hermes nests the message and fields in a "fields" object.
+	if fields, ok := raw["fields"].(map[string]any); ok {
+		delete(raw, "fields")
+		for k, v := range fields {
+			raw[k] = v
+		}
+	}
+
+	// rly names the level key "lvl".
+	var l LogLine
+	for _, k := range []string{"level", "lvl"} {
+		if level, ok := raw[k].(string); ok {
+			l.Level = strings.ToLower(level)
+			delete(raw, k)
+			break
+		}
+	}
+	if l.Level == "" {
+		return LogLine{}, false
+	}
+	l.Fields = map[string]string{}
+
+	for _, k := range []string{"msg", "message"} {
+		if msg, ok := raw[k].(string); ok {
+			l.Message = msg
+			delete(raw, k)
+			break
+		}
+	}
+	for _, k := range []string{"ts", "timestamp"} {
+		if ts, ok := raw[k].(string); ok {
+			l.Time = parseLogTime(ts)
+			delete(raw, k)
+			break
+		}
+	}
+
+	for k, v := range raw {
+		l.Fields[k] = logFieldValue(v)
+	}
+	return l, true
+}
+
+// parseConsoleLogLine parses a line of tab-separated timestamp, level, message,
+// and optionally a JSON object of fields.
+func parseConsoleLogLine(line string) (LogLine, bool) {
+	parts := strings.SplitN(line, "\t", 4)
+	if len(parts) < 3 {
+		return LogLine{}, false
+	}
+	t := parseLogTime(parts[0])
+	if t.IsZero() {
+		return LogLine{}, false
+	}
+
+	l := LogLine{
+		Time:    t,
+		Level:   strings.ToLower(parts[1]),
+		Message: parts[2],
+		Fields:  map[string]string{},
+	}
+	if len(parts) == 4 {
+		var fields map[string]any
+		if err := json.Unmarshal([]byte(parts[3]), &fields); err != nil {
+			// Not a fields object, so keep it as part of the message.
+			l.Message += "\t" + parts[3]
+		}
+		for k, v := range fields {
+			l.Fields[k] = logFieldValue(v)
+		}
+	}
+	return l, true
+}
+
+func parseLogTime(s string) time.Time {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.000Z0700"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func logFieldValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// AddLogSink adds a sink receiving the parsed lines logged by the relayer started by StartRelayer.
+// It must be called before StartRelayer.
+func (r *DockerRelayer) AddLogSink(sink LogSink) {
+	r.logSinks = append(r.logSinks, sink)
+}
+
+// LogFilePath returns the path of the file on the host to which the logs of the relayer started by StartRelayer
+// are written, named after the relayer, the test, and the relayer instance.
+// The file is truncated the first time the relayer is started, and appended to if it is restarted.
+func (r *DockerRelayer) LogFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("user home dir: %w", err)
+	}
+	// Alongside the files created by ibctest.CreateLogFile.
+	return filepath.Join(home, ".ibctest", "logs", "relayers", r.Name()+"-"+r.instance+".log"), nil
+}
+
+func (r *DockerRelayer) openLogFile() (*os.File, error) {
+	fpath, err := r.LogFilePath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(fpath), 0755); err != nil {
+		return nil, fmt.Errorf("mkdirall: %w", err)
+	}
+
+	flag := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if !r.logFileCreated {
+		flag |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(fpath, flag, 0644)
+	if err != nil {
+		return nil, err
+	}
+	r.logFileCreated = true
+	return f, nil
+}
+
+// streamLogs follows the logs of the container started by StartRelayer until it stops,
+// writing them to the relayer's log file and passing the parsed lines to rep,
+// if it is an ibc.RelayerLogReporter, and to the log sinks.
+// It closes ready once lines show that the relayer is relaying on all the chains in chainIDs,
+// and closes done when the container's logs end.
+func (r *DockerRelayer) streamLogs(ctx context.Context, rep ibc.RelayerExecReporter, containerName string, chainIDs map[string]bool, done, ready chan<- struct{}) {
+	defer close(done)
+
+	var out io.Writer = io.Discard
+	f, err := r.openLogFile()
+	if err != nil {
+		r.log.Info("Failed to open relayer log file", zap.Error(err))
+	} else {
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	rc, err := r.client.ContainerLogs(ctx, r.containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Timestamps: true,
+	})
+	if err != nil {
+		r.log.Info("Failed to follow relayer logs", zap.String("container", containerName), zap.Error(err))
+		return
+	}
+	defer func() { _ = rc.Close() }()
+
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	pr, pw := io.Pipe()
+	defer func() { _ = pr.Close() }()
+	go func() {
+		_, err := stdcopy.StdCopy(pw, pw, rc)
+		_ = pw.CloseWithError(err)
+	}()
+
+	logReporter, _ := rep.(ibc.RelayerLogReporter)
+
+	scanner := bufio.NewScanner(pr)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		// With timestamps requested, Docker prefixes each line with the time it was logged.
+		ts, text, _ := strings.Cut(scanner.Text(), " ")
+		_, _ = fmt.Fprintln(out, text)
+
+		line := ParseLogLine(text)
+		if line.Time.IsZero() {
+			line.Time = parseLogTime(ts)
+		}
+		if logReporter != nil {
+			logReporter.TrackRelayerLog(containerName, line.Time, line.Level, line.Message, line.Fields)
+		}
+		for _, sink := range r.logSinks {
+			sink(containerName, line)
+		}
+		if ready != nil && r.isReadyLogLine(line, chainIDs) {
+			close(ready)
+			ready = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		r.log.Info("Failed to read relayer logs", zap.String("container", containerName), zap.Error(err))
+	}
+}
+
+// isReadyLogLine reports whether, with line, the relayer has shown that it is relaying,
+// as recognized by the relayer's commander if it is a ReadinessCommander.
+// The IDs of chains on which the relayer has shown that it is relaying are removed from pending,
+// and the relayer is relaying once none remain.
+func (r *DockerRelayer) isReadyLogLine(line LogLine, pending map[string]bool) bool {
+	rc, ok := r.c.(ReadinessCommander)
+	if !ok {
+		return true
+	}
+	ready, chainID := rc.IsReadyLogLine(line)
+	if !ready {
+		return false
+	}
+	if chainID == "" {
+		return true
+	}
+	delete(pending, chainID)
+	return len(pending) == 0
+}
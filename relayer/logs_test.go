@@ -0,0 +1,113 @@
+package relayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLine(t *testing.T) {
+	loggedAt := time.Date(2022, 8, 1, 12, 0, 0, 123000000, time.UTC)
+
+	for _, tt := range []struct {
+		Name, Line string
+		Want       LogLine
+	}{
+		{
+			// rly started with --log-format json names the level key "lvl",
+			// logs times with microseconds, and adds the chain of a chain processor to each of its lines.
+			Name: "rly json",
+			Line: `{"lvl":"info","ts":"2022-08-01T12:00:00.123000Z","msg":"Chain is in sync","chain_name":"gaia-1","chain_id":"gaia-1"}` + "\n",
+			Want: LogLine{
+				Time:    loggedAt,
+				Level:   "info",
+				Message: "Chain is in sync",
+				Fields:  map[string]string{"chain_name": "gaia-1", "chain_id": "gaia-1"},
+			},
+		},
+		{
+			Name: "rly json with typed fields",
+			Line: `{"lvl":"debug","ts":"2022-08-01T12:00:00.123000Z","msg":"Queried block","chain_id":"gaia-1","height":42,"latest":true}`,
+			Want: LogLine{
+				Time:    loggedAt,
+				Level:   "debug",
+				Message: "Queried block",
+				Fields:  map[string]string{"chain_id": "gaia-1", "height": "42", "latest": "true"},
+			},
+		},
+		{
+			Name: "zap console",
+			Line: "2022-08-01T12:00:00.123Z\tinfo\tSuccessful transaction\t{\"chain_id\": \"gaia-1\", \"gas_used\": 1234}",
+			Want: LogLine{
+				Time:    loggedAt,
+				Level:   "info",
+				Message: "Successful transaction",
+				Fields:  map[string]string{"chain_id": "gaia-1", "gas_used": "1234"},
+			},
+		},
+		{
+			Name: "zap json",
+			Line: `{"level":"error","ts":"2022-08-01T12:00:00.123Z","msg":"Failed to relay","error":"timeout"}`,
+			Want: LogLine{
+				Time:    loggedAt,
+				Level:   "error",
+				Message: "Failed to relay",
+				Fields:  map[string]string{"error": "timeout"},
+			},
+		},
+		{
+			Name: "hermes json",
+			Line: `{"timestamp":"2022-08-01T12:00:00.123Z","level":"INFO","fields":{"message":"scanned chains","chains":2},"target":"ibc_relayer_cli"}`,
+			Want: LogLine{
+				Time:    loggedAt,
+				Level:   "info",
+				Message: "scanned chains",
+				Fields:  map[string]string{"chains": "2", "target": "ibc_relayer_cli"},
+			},
+		},
+		{
+			Name: "unrecognized",
+			Line: "Error: connection refused",
+			Want: LogLine{Message: "Error: connection refused"},
+		},
+		{
+			Name: "json without level",
+			Line: `{"result":"ok"}`,
+			Want: LogLine{Message: `{"result":"ok"}`},
+		},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			require.Equal(t, tt.Want, ParseLogLine(tt.Line))
+		})
+	}
+}
+
+// chainReadinessCommander reports readiness per chain, as rly does.
+type chainReadinessCommander struct {
+	RelayerCommander
+}
+
+func (chainReadinessCommander) IsReadyLogLine(line LogLine) (bool, string) {
+	return line.Message == "Chain is in sync", line.Fields["chain_id"]
+}
+
+func TestDockerRelayer_isReadyLogLine(t *testing.T) {
+	r := &DockerRelayer{c: chainReadinessCommander{}}
+	synced := func(chainID string) LogLine {
+		return LogLine{Message: "Chain is in sync", Fields: map[string]string{"chain_id": chainID}}
+	}
+
+	pending := map[string]bool{"gaia-1": true, "osmosis-1": true}
+	require.False(t, r.isReadyLogLine(LogLine{Message: "Starting"}, pending))
+	require.False(t, r.isReadyLogLine(synced("gaia-1"), pending))
+	// Repeated lines for the same chain do not count for the other one.
+	require.False(t, r.isReadyLogLine(synced("gaia-1"), pending))
+	require.True(t, r.isReadyLogLine(synced("osmosis-1"), pending))
+
+	// Without known chains, the first ready line suffices.
+	require.True(t, r.isReadyLogLine(synced("gaia-1"), map[string]bool{}))
+
+	// A line about no particular chain shows the relayer is ready on all of them.
+	require.True(t, r.isReadyLogLine(LogLine{Message: "Chain is in sync"}, map[string]bool{"gaia-1": true}))
+}
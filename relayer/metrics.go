@@ -0,0 +1,146 @@
+package relayer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MetricSample is a single sample of a Prometheus metric.
+type MetricSample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// Metrics is the set of samples scraped from a relayer's metrics endpoint.
+type Metrics []MetricSample
+
+// Sum returns the sum of the values of the samples of the named metric
+// which have all of the given labels, e.g. every packet relayed on a path.
+func (m Metrics) Sum(name string, labels map[string]string) float64 {
+	var sum float64
+	for _, s := range m {
+		if s.Name == name && s.hasLabels(labels) {
+			sum += s.Value
+		}
+	}
+	return sum
+}
+
+func (s MetricSample) hasLabels(labels map[string]string) bool {
+	for k, v := range labels {
+		if s.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseMetrics parses metrics in the Prometheus text exposition format.
+// Comments, including HELP and TYPE lines, and sample timestamps are ignored.
+func ParseMetrics(r io.Reader) (Metrics, error) {
+	var m Metrics
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		s, err := parseMetricSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		m = append(m, s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading metrics: %w", err)
+	}
+	return m, nil
+}
+
+// parseMetricSample parses a line of the form `name{label="value",...} value [timestamp]`.
+func parseMetricSample(line string) (MetricSample, error) {
+	s := MetricSample{Labels: map[string]string{}}
+
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return s, fmt.Errorf("invalid sample %q", line)
+	}
+	s.Name = line[:end]
+	rest := line[end:]
+
+	if rest[0] == '{' {
+		var err error
+		rest, err = parseMetricLabels(rest[1:], s.Labels)
+		if err != nil {
+			return s, fmt.Errorf("labels of %s: %w", s.Name, err)
+		}
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return s, fmt.Errorf("invalid value of %s: %q", s.Name, rest)
+	}
+	v, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return s, fmt.Errorf("invalid value of %s: %w", s.Name, err)
+	}
+	s.Value = v
+	return s, nil
+}
+
+// parseMetricLabels parses the labels following the opening brace into labels,
+// returning the remainder of the line after the closing brace.
+func parseMetricLabels(in string, labels map[string]string) (string, error) {
+	for {
+		in = strings.TrimLeft(in, " \t,")
+		if in == "" {
+			return "", fmt.Errorf("missing closing brace")
+		}
+		if in[0] == '}' {
+			return in[1:], nil
+		}
+
+		eq := strings.IndexByte(in, '=')
+		if eq <= 0 || len(in) < eq+2 || in[eq+1] != '"' {
+			return "", fmt.Errorf("invalid label %q", in)
+		}
+		key := strings.TrimSpace(in[:eq])
+		in = in[eq+2:]
+
+		// Label values escape backslashes, double quotes and line feeds.
+		var (
+			value   strings.Builder
+			escaped bool
+			closed  bool
+		)
+		for i := 0; i < len(in); i++ {
+			c := in[i]
+			switch {
+			case escaped:
+				if c == 'n' {
+					c = '\n'
+				}
+				value.WriteByte(c)
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				in = in[i+1:]
+				closed = true
+			default:
+				value.WriteByte(c)
+			}
+			if closed {
+				break
+			}
+		}
+		if !closed {
+			return "", fmt.Errorf("unterminated value of label %s", key)
+		}
+		labels[key] = value.String()
+	}
+}
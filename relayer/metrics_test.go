@@ -0,0 +1,63 @@
+package relayer
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetrics(t *testing.T) {
+	t.Run("samples", func(t *testing.T) {
+		const text = `# HELP cosmos_relayer_relayed_packets The total number of packets relayed
+# TYPE cosmos_relayer_relayed_packets counter
+cosmos_relayer_relayed_packets{chain="gaia-1",channel="channel-0",path="p",port="transfer",type="MsgRecvPacket"} 3
+cosmos_relayer_relayed_packets{chain="osmosis-1",channel="channel-0",path="p",port="transfer",type="MsgAcknowledgement"} 2 1660000000000
+
+cosmos_relayer_fees_spent{chain="gaia-1",denom="uatom", key="default"} 1.5e3
+process_open_fds 12
+weird{msg="a \"quoted\", escaped\\ value\non two lines",} NaN
+`
+		m, err := ParseMetrics(strings.NewReader(text))
+		require.NoError(t, err)
+		require.Len(t, m, 5)
+
+		require.Equal(t, MetricSample{
+			Name: "cosmos_relayer_relayed_packets",
+			Labels: map[string]string{
+				"chain":   "gaia-1",
+				"channel": "channel-0",
+				"path":    "p",
+				"port":    "transfer",
+				"type":    "MsgRecvPacket",
+			},
+			Value: 3,
+		}, m[0])
+		require.Equal(t, MetricSample{Name: "process_open_fds", Labels: map[string]string{}, Value: 12}, m[3])
+		require.Equal(t, "a \"quoted\", escaped\\ value\non two lines", m[4].Labels["msg"])
+		require.True(t, math.IsNaN(m[4].Value))
+
+		require.Equal(t, float64(5), m.Sum("cosmos_relayer_relayed_packets", map[string]string{"path": "p"}))
+		require.Equal(t, float64(2), m.Sum("cosmos_relayer_relayed_packets", map[string]string{"type": "MsgAcknowledgement"}))
+		require.Equal(t, float64(1500), m.Sum("cosmos_relayer_fees_spent", nil))
+		require.Zero(t, m.Sum("cosmos_relayer_fees_spent", map[string]string{"chain": "osmosis-1"}))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, tt := range []struct {
+			Line, Err string
+		}{
+			{`name{chain="gaia" 1`, "labels of name"},
+			{`name{chain=gaia} 1`, "invalid label"},
+			{`name{chain="gaia} 1`, "unterminated value"},
+			{`name one`, "invalid value of name"},
+			{`name`, "invalid sample"},
+			{`name 1 2 3`, "invalid value of name"},
+		} {
+			_, err := ParseMetrics(strings.NewReader("# comment\n" + tt.Line))
+			require.ErrorContains(t, err, "line 2: ", tt.Line)
+			require.ErrorContains(t, err, tt.Err, tt.Line)
+		}
+	})
+}
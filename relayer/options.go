@@ -50,3 +50,28 @@ func StartupFlags(flags ...string) RelayerOption {
 }
 
 func (opt RelayerOptionExtraStartFlags) relayerOption() {}
+
+// RelayerOptionMetrics enables the metrics endpoint of the relayer started by StartRelayer,
+// so that tests can scrape it from the host.
+type RelayerOptionMetrics struct{}
+
+// EnableMetrics returns a RelayerOption serving the relayer's Prometheus metrics while it runs.
+// Constructing a relayer that does not support metrics with this option fails.
+func EnableMetrics() RelayerOption {
+	return RelayerOptionMetrics{}
+}
+
+func (opt RelayerOptionMetrics) relayerOption() {}
+
+// RelayerOptionChannelHandshakes makes the relayer complete, while running,
+// channel handshakes that were started by applications, such as interchain account registration.
+type RelayerOptionChannelHandshakes struct{}
+
+// CompleteChannelHandshakes returns a RelayerOption enabling the relayer to complete channel handshakes
+// started on chain by applications rather than by the relayer itself.
+// Relayers that always complete such handshakes ignore this option.
+func CompleteChannelHandshakes() RelayerOption {
+	return RelayerOptionChannelHandshakes{}
+}
+
+func (opt RelayerOptionChannelHandshakes) relayerOption() {}
@@ -25,6 +25,8 @@ func NewCosmosRelayer(log *zap.Logger, testName string, cli *client.Client, netw
 		switch o := opt.(type) {
 		case relayer.RelayerOptionExtraStartFlags:
 			c.extraStartFlags = o.Flags
+		case relayer.RelayerOptionMetrics:
+			c.metrics = true
 		}
 	}
 	dr, err := relayer.NewDockerRelayer(context.TODO(), log, testName, cli, networkID, c, options...)
@@ -59,18 +61,58 @@ type CosmosRelayerChainConfig struct {
 	Value CosmosRelayerChainConfigValue `json:"value"`
 }
 
+// CosmosRelayerPath is the content of a path file for "rly paths add".
+type CosmosRelayerPath struct {
+	Src              CosmosRelayerPathEnd       `json:"src"`
+	Dst              CosmosRelayerPathEnd       `json:"dst"`
+	SrcChannelFilter CosmosRelayerChannelFilter `json:"src-channel-filter"`
+}
+
+type CosmosRelayerPathEnd struct {
+	ChainID      string `json:"chain-id"`
+	ClientID     string `json:"client-id"`
+	ConnectionID string `json:"connection-id"`
+}
+
+type CosmosRelayerChannelFilter struct {
+	Rule        string   `json:"rule"`
+	ChannelList []string `json:"channel-list"`
+}
+
 const (
 	DefaultContainerImage   = "ghcr.io/cosmos/relayer"
 	DefaultContainerVersion = "v2.0.0-rc3"
 )
 
+// Names of the metrics served by rly when constructed with the relayer.EnableMetrics option.
+// rly serves them as of v2.2.0, so a relayer with metrics needs a relayer.CustomDockerImage
+// newer than DefaultContainerVersion.
+const (
+	// Packets relayed, labeled by path, chain, channel, port and message type.
+	MetricRelayedPackets = "cosmos_relayer_relayed_packets"
+
+	// Fees spent by the relayer's wallet, labeled by chain, key, address and denom.
+	MetricFeesSpent = "cosmos_relayer_fees_spent"
+)
+
+// metricsAddr is the address of rly's debug server, which serves metrics at metricsPath.
+const (
+	metricsAddr = "0.0.0.0:7597"
+	metricsPort = "7597/tcp"
+	metricsPath = "/relayer/metrics"
+)
+
 // Capabilities returns the set of capabilities of the Cosmos relayer.
 //
 // Note, this API may change if the rly package eventually needs
 // to distinguish between multiple rly versions.
 func Capabilities() map[relayer.Capability]bool {
-	// RC1 matches the full set of capabilities as of writing.
-	return relayer.FullCapabilities()
+	c := relayer.FullCapabilities()
+
+	// rly does not watch for conflicting client updates as of writing.
+	c[relayer.Misbehaviour] = false
+
+	return c
 }
 
 func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName, rpcAddr, gprcAddr string) CosmosRelayerChainConfig {
@@ -97,8 +139,14 @@ func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName,
 type commander struct {
 	log             *zap.Logger
 	extraStartFlags []string
+	metrics         bool
 }
 
+var (
+	_ relayer.EfficiencyCommander = commander{}
+	_ relayer.ReadinessCommander  = commander{}
+)
+
 func (commander) Name() string {
 	return "rly"
 }
@@ -121,6 +169,21 @@ func (commander) AddKey(chainID, keyName, homeDir string) []string {
 	}
 }
 
+func (commander) AddPath(srcChainID, dstChainID, pathName, containerFilePath, homeDir string) []string {
+	return []string{
+		"rly", "paths", "add", srcChainID, dstChainID, pathName,
+		"--file", containerFilePath,
+		"--home", homeDir,
+	}
+}
+
+func (commander) CloseChannel(pathName, portID, channelID, homeDir string) []string {
+	return []string{
+		"rly", "tx", "channel-close", pathName, channelID, portID,
+		"--home", homeDir,
+	}
+}
+
 func (commander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) []string {
 	return []string{
 		"rly", "tx", "channel", pathName,
@@ -201,10 +264,18 @@ func (commander) RestoreKey(chainID, keyName, mnemonic, homeDir string) []string
 	}
 }
 
-func (c commander) StartRelayer(pathName, homeDir string) []string {
-	cmd := []string{
-		"rly", "start", pathName, "--debug",
+func (c commander) StartRelayer(homeDir string, pathNames ...string) []string {
+	cmd := []string{"rly", "start"}
+	cmd = append(cmd, pathNames...)
+	cmd = append(cmd,
+		"--debug",
 		"--home", homeDir,
+		// Without a terminal, rly logs in logfmt by default; JSON is parsed by relayer.ParseLogLine.
+		"--log-format", "json",
+	)
+	if c.metrics {
+		// The debug server listens on localhost by default, which is not reachable through a published port.
+		cmd = append(cmd, "--debug-addr", metricsAddr)
 	}
 	cmd = append(cmd, c.extraStartFlags...)
 	return cmd
@@ -226,6 +297,49 @@ func (commander) ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName
 	return jsonBytes, nil
 }
 
+// PathConfigContent returns a path file for "rly paths add",
+// without a channel filter so that every channel on the connection is relayed.
+func (commander) PathConfigContent(src, dst ibc.PathEnd) ([]byte, error) {
+	p := CosmosRelayerPath{
+		Src: CosmosRelayerPathEnd{ChainID: src.ChainID, ClientID: src.ClientID, ConnectionID: src.ConnectionID},
+		Dst: CosmosRelayerPathEnd{ChainID: dst.ChainID, ClientID: dst.ClientID, ConnectionID: dst.ConnectionID},
+		SrcChannelFilter: CosmosRelayerChannelFilter{
+			ChannelList: []string{},
+		},
+	}
+	return json.Marshal(p)
+}
+
+func (commander) MetricsPort() string {
+	return metricsPort
+}
+
+func (commander) MetricsPath() string {
+	return metricsPath
+}
+
+// Efficiency counts every packet message rly submitted, on any path, chain or channel,
+// and sums the fees spent by its wallets on every chain by denom.
+func (commander) Efficiency(m relayer.Metrics) (uint64, map[string]float64) {
+	fees := map[string]float64{}
+	for _, s := range m {
+		if s.Name == MetricFeesSpent {
+			fees[s.Labels["denom"]] += s.Value
+		}
+	}
+	return uint64(m.Sum(MetricRelayedPackets, nil)), fees
+}
+
+// IsReadyLogLine reports whether line is logged by a chain processor of rly once it has caught up with its chain,
+// after which rly relays the packets and acknowledgements it observes on that chain.
+// Each chain processor logs the line with the ID of its chain, so rly is ready once it was logged for every chain.
+func (commander) IsReadyLogLine(line relayer.LogLine) (bool, string) {
+	if line.Message != "Chain is in sync" {
+		return false, ""
+	}
+	return true, line.Fields["chain_id"]
+}
+
 func (commander) DefaultContainerImage() string {
 	return DefaultContainerImage
 }
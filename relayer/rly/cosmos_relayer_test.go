@@ -0,0 +1,49 @@
+package rly
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCommander_StartRelayer_LogFormat(t *testing.T) {
+	c := commander{log: zap.NewNop()}
+
+	// Readiness is detected from the parsed log lines, so rly must log JSON rather than its default logfmt.
+	cmd := c.StartRelayer("/home/relayer", "p")
+	require.Contains(t, strings.Join(cmd, " "), "--log-format json")
+}
+
+func TestCommander_IsReadyLogLine(t *testing.T) {
+	c := commander{log: zap.NewNop()}
+
+	ready, chainID := c.IsReadyLogLine(relayer.ParseLogLine(
+		`{"lvl":"info","ts":"2022-08-01T12:00:00.123000Z","msg":"Chain is in sync","chain_name":"osmosis-1","chain_id":"osmosis-1"}`,
+	))
+	require.True(t, ready)
+	require.Equal(t, "osmosis-1", chainID)
+
+	ready, _ = c.IsReadyLogLine(relayer.ParseLogLine(
+		`{"lvl":"info","ts":"2022-08-01T12:00:00.123000Z","msg":"Chain is not yet in sync","chain_name":"osmosis-1","chain_id":"osmosis-1","latest_queried_block":10,"latest_height":20}`,
+	))
+	require.False(t, ready)
+}
+
+func TestCommander_Efficiency(t *testing.T) {
+	m, err := relayer.ParseMetrics(strings.NewReader(`# TYPE cosmos_relayer_relayed_packets counter
+cosmos_relayer_relayed_packets{chain="gaia-1",channel="channel-0",path="p",port="transfer",type="MsgRecvPacket"} 3
+cosmos_relayer_relayed_packets{chain="osmosis-1",channel="channel-0",path="p",port="transfer",type="MsgAcknowledgement"} 2
+cosmos_relayer_fees_spent{address="cosmos1abc",chain="gaia-1",denom="uatom",gas_price="0.01uatom",key="default"} 1500
+cosmos_relayer_fees_spent{address="osmo1abc",chain="osmosis-1",denom="uosmo",gas_price="0.0025uosmo",key="default"} 250
+cosmos_relayer_fees_spent{address="cosmos1def",chain="gaia-2",denom="uatom",gas_price="0.01uatom",key="default"} 500
+cosmos_relayer_chain_latest_height{chain="gaia-1"} 42
+`))
+	require.NoError(t, err)
+
+	packets, fees := commander{log: zap.NewNop()}.Efficiency(m)
+	require.Equal(t, uint64(5), packets)
+	require.Equal(t, map[string]float64{"uatom": 2000, "uosmo": 250}, fees)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/strangelove-ventures/ibctest/ibc"
 	"github.com/strangelove-ventures/ibctest/label"
 	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/relayer/hermes"
 	"github.com/strangelove-ventures/ibctest/relayer/rly"
 	"go.uber.org/zap"
 )
@@ -38,8 +39,18 @@ type RelayerFactory interface {
 	Capabilities() map[relayer.Capability]bool
 }
 
+// OptionsRelayerFactory is a RelayerFactory whose relayers can be customized with additional options,
+// for tests that depend on relayer behavior which is not enabled by default.
+type OptionsRelayerFactory interface {
+	RelayerFactory
+
+	// WithOptions returns a factory building the same relayers as this one,
+	// with options appended to the ones this factory was created with.
+	WithOptions(options ...relayer.RelayerOption) RelayerFactory
+}
+
 // builtinRelayerFactory is the built-in relayer factory that understands
-// how to start the cosmos relayer in a docker container.
+// how to start the cosmos relayer or Hermes in a docker container.
 type builtinRelayerFactory struct {
 	impl    ibc.RelayerImplementation
 	log     *zap.Logger
@@ -50,6 +61,12 @@ func NewBuiltinRelayerFactory(impl ibc.RelayerImplementation, logger *zap.Logger
 	return builtinRelayerFactory{impl: impl, log: logger, options: options}
 }
 
+// WithOptions returns a copy of f with options appended to its options.
+func (f builtinRelayerFactory) WithOptions(options ...relayer.RelayerOption) RelayerFactory {
+	f.options = append(append(relayer.RelayerOptions(nil), f.options...), options...)
+	return f
+}
+
 // Build returns a relayer chosen depending on f.impl.
 func (f builtinRelayerFactory) Build(
 	t *testing.T,
@@ -65,6 +82,14 @@ func (f builtinRelayerFactory) Build(
 			networkID,
 			f.options...,
 		)
+	case ibc.Hermes:
+		return hermes.NewHermesRelayer(
+			f.log,
+			t.Name(),
+			cli,
+			networkID,
+			f.options...,
+		)
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
@@ -83,6 +108,14 @@ func (f builtinRelayerFactory) Name() string {
 			}
 		}
 		return "rly@" + rly.DefaultContainerVersion
+	case ibc.Hermes:
+		for _, opt := range f.options {
+			switch o := opt.(type) {
+			case relayer.RelayerOptionDockerImage:
+				return "hermes@" + o.DockerImage.Version
+			}
+		}
+		return "hermes@" + hermes.DefaultContainerVersion
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
@@ -92,6 +125,8 @@ func (f builtinRelayerFactory) Labels() []label.Relayer {
 	switch f.impl {
 	case ibc.CosmosRly:
 		return []label.Relayer{label.Rly}
+	case ibc.Hermes:
+		return []label.Relayer{label.Hermes}
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
@@ -103,6 +138,8 @@ func (f builtinRelayerFactory) Capabilities() map[relayer.Capability]bool {
 	switch f.impl {
 	case ibc.CosmosRly:
 		return rly.Capabilities()
+	case ibc.Hermes:
+		return hermes.Capabilities()
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
@@ -0,0 +1,23 @@
+package ibctest_test
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBuiltinRelayerFactory_WithOptions(t *testing.T) {
+	rf := ibctest.NewBuiltinRelayerFactory(ibc.Hermes, zap.NewNop(), relayer.CustomDockerImage("hermes", "custom"))
+
+	of, ok := rf.(ibctest.OptionsRelayerFactory)
+	require.True(t, ok)
+
+	withOpts := of.WithOptions(relayer.CompleteChannelHandshakes())
+	require.Equal(t, "hermes@custom", withOpts.Name())
+	require.Equal(t, rf.Name(), withOpts.Name())
+	require.Equal(t, rf.Capabilities(), withOpts.Capabilities())
+}
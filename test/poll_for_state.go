@@ -48,6 +48,31 @@ func PollForTimeout(ctx context.Context, chain ChainTimeouter, startHeight, maxH
 	return found.(ibc.PacketTimeout), nil
 }
 
+// ChainAckTimeouter is a chain that can get its acknowledgements and timeouts at a specified height
+type ChainAckTimeouter interface {
+	ChainAcker
+	Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error)
+}
+
+// PollForAckOrTimeout attempts to find either an acknowledgement or a timeout containing a packet equal to the packet argument,
+// for when it is not known in advance whether the packet is relayed before it times out.
+// Exactly one of the returned acknowledgement and timeout is non-nil if the error is nil.
+// Otherwise, works identically to PollForAck.
+func PollForAckOrTimeout(ctx context.Context, chain ChainAckTimeouter, startHeight, maxHeight uint64, packet ibc.Packet) (*ibc.PacketAcknowledgement, *ibc.PacketTimeout, error) {
+	poller := blockPoller{CurrentHeight: chain.Height, Acker: chain, Timeouter: chain}
+	found, err := poller.doPoll(ctx, startHeight, maxHeight, packet)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch found := found.(type) {
+	case ibc.PacketAcknowledgement:
+		return &found, nil, nil
+	default:
+		timeout := found.(ibc.PacketTimeout)
+		return nil, &timeout, nil
+	}
+}
+
 type blockPoller struct {
 	CurrentHeight func(ctx context.Context) (uint64, error)
 	Acker         ChainAcker
@@ -76,6 +101,11 @@ func (p blockPoller) doPoll(ctx context.Context, startHeight, maxHeight uint64,
 			findErr error
 		)
 		switch {
+		case p.Acker != nil && p.Timeouter != nil:
+			found, findErr = p.findAck(ctx, cursor, packet)
+			if findErr != nil {
+				found, findErr = p.findTimeout(ctx, cursor, packet)
+			}
 		case p.Acker != nil:
 			found, findErr = p.findAck(ctx, cursor, packet)
 		case p.Timeouter != nil:
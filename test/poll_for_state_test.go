@@ -160,3 +160,43 @@ func TestPollForTimeout(t *testing.T) {
 		})
 	})
 }
+
+func TestPollForAckOrTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ack", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1, FoundAcks: []ibc.PacketAcknowledgement{
+			{Packet: ibc.Packet{Sequence: 33, SourceChannel: "found"}},
+		}}
+		ack, timeout, err := PollForAckOrTimeout(ctx, &chain, 3, 5, ibc.Packet{Sequence: 33, SourceChannel: "found"})
+
+		require.NoError(t, err)
+		require.Nil(t, timeout)
+		require.NotNil(t, ack)
+		require.EqualValues(t, 33, ack.Packet.Sequence)
+		require.Equal(t, []uint64{3}, chain.GotHeights)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1, FoundTimeouts: []ibc.PacketTimeout{
+			{Packet: ibc.Packet{Sequence: 33, SourceChannel: "found"}},
+		}}
+		ack, timeout, err := PollForAckOrTimeout(ctx, &chain, 3, 5, ibc.Packet{Sequence: 33, SourceChannel: "found"})
+
+		require.NoError(t, err)
+		require.Nil(t, ack)
+		require.NotNil(t, timeout)
+		require.EqualValues(t, 33, timeout.Packet.Sequence)
+		// Acknowledgements are searched first.
+		require.Equal(t, []uint64{3, 3}, chain.GotHeights)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1}
+		_, _, err := PollForAckOrTimeout(ctx, &chain, 1, 2, ibc.Packet{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Equal(t, []uint64{1, 1, 2, 2}, chain.GotHeights)
+	})
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"sync"
 	"testing"
-	"time"
 
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/ibctest/ibc"
@@ -112,17 +111,9 @@ func StartChainPairAndRelayer(
 	}
 	wg.Wait()
 
-	if err := relayerImpl.StartRelayer(ctx, eRep, testPathName); err != nil {
-		return errResponse(fmt.Errorf("failed to start relayer: %w", err))
+	if err := ic.StartRelayers(t, ctx, eRep); err != nil {
+		return errResponse(err)
 	}
-	t.Cleanup(func() {
-		if err := relayerImpl.StopRelayer(ctx, eRep); err != nil {
-			t.Logf("error stopping relayer: %v", err)
-		}
-	})
-
-	// wait for relayer to start up
-	time.Sleep(5 * time.Second)
 
 	return relayerImpl, channels, nil
 }
@@ -142,6 +142,48 @@ func (m RelayerExecMessage) typ() string {
 	return "RelayerExec"
 }
 
+// RelayerLogMessage is a line logged by a long-running relayer, such as one started by StartRelayer.
+// This message is populated through the RelayerExecReporter type,
+// which is returned by the Reporter's RelayerExecReporter method.
+type RelayerLogMessage struct {
+	Name string // Test name, but "Name" for consistency.
+
+	When time.Time
+
+	ContainerName string `json:",omitempty"`
+
+	// Level is empty, and Message is the whole line, if the relayer's log format was not recognized.
+	Level   string `json:",omitempty"`
+	Message string
+
+	Fields map[string]string `json:",omitempty"`
+}
+
+func (m RelayerLogMessage) typ() string {
+	return "RelayerLog"
+}
+
+// RelayerEfficiencyMessage is how much a long-running relayer relayed, and what it spent doing so,
+// as read from the relayer's metrics before it was stopped.
+// This message is populated through the RelayerExecReporter type,
+// which is returned by the Reporter's RelayerExecReporter method.
+type RelayerEfficiencyMessage struct {
+	Name string // Test name, but "Name" for consistency.
+
+	When time.Time
+
+	ContainerName string `json:",omitempty"`
+
+	PacketsRelayed uint64
+
+	// Fees paid by the relayer's wallets, keyed by denom.
+	FeesSpent map[string]float64 `json:",omitempty"`
+}
+
+func (m RelayerEfficiencyMessage) typ() string {
+	return "RelayerEfficiency"
+}
+
 // WrappedMessage wraps a Message with an outer Type field
 // so that decoders can determine the underlying message's type.
 type WrappedMessage struct {
@@ -208,6 +250,14 @@ func (m *WrappedMessage) UnmarshalJSON(b []byte) error {
 		x := RelayerExecMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "RelayerLog":
+		x := RelayerLogMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
+	case "RelayerEfficiency":
+		x := RelayerEfficiencyMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	default:
 		return fmt.Errorf("unknown message type %q", outer.Type)
 	}
@@ -45,6 +45,25 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 				Error:         "",
 			},
 		},
+		{
+			Message: testreporter.RelayerLogMessage{
+				Name:          "foo",
+				When:          time.Now(),
+				ContainerName: "rly-p",
+				Level:         "info",
+				Message:       "Successful transaction",
+				Fields:        map[string]string{"chain_id": "gaia-1"},
+			},
+		},
+		{
+			Message: testreporter.RelayerEfficiencyMessage{
+				Name:           "foo",
+				When:           time.Now(),
+				ContainerName:  "rly-p",
+				PacketsRelayed: 4,
+				FeesSpent:      map[string]float64{"uatom": 1500},
+			},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/strangelove-ventures/ibctest/label"
@@ -29,6 +30,11 @@ type Reporter struct {
 	in chan Message
 
 	writerDone chan error
+
+	// Guards closed, so that relayer log lines, which are tracked by goroutines
+	// that may outlive the tests, are dropped once the reporter is closed rather than sent on a closed channel.
+	mu     sync.RWMutex
+	closed bool
 }
 
 func NewReporter(w io.WriteCloser) *Reporter {
@@ -64,6 +70,10 @@ func (r *Reporter) write() {
 // Close closes the reporter and blocks until its results are flushed
 // to the underlying writer.
 func (r *Reporter) Close() error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+
 	r.in <- FinishSuiteMessage{
 		FinishedAt: time.Now(),
 	}
@@ -188,6 +198,48 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 	}
 }
 
+// TrackRelayerLog tracks a line logged by a long-running relayer.
+// It satisfies the ibc.RelayerLogReporter interface.
+// Lines tracked after the reporter is closed are dropped.
+func (r *RelayerExecReporter) TrackRelayerLog(
+	containerName string,
+	when time.Time,
+	level, message string,
+	fields map[string]string,
+) {
+	r.r.mu.RLock()
+	defer r.r.mu.RUnlock()
+	if r.r.closed {
+		return
+	}
+
+	r.r.in <- RelayerLogMessage{
+		Name:          r.testName,
+		When:          when,
+		ContainerName: containerName,
+		Level:         level,
+		Message:       message,
+		Fields:        fields,
+	}
+}
+
+// TrackRelayerEfficiency tracks how much a long-running relayer relayed and what it spent doing so.
+// It satisfies the ibc.RelayerEfficiencyReporter interface.
+func (r *RelayerExecReporter) TrackRelayerEfficiency(
+	containerName string,
+	when time.Time,
+	packetsRelayed uint64,
+	feesSpent map[string]float64,
+) {
+	r.r.in <- RelayerEfficiencyMessage{
+		Name:           r.testName,
+		When:           when,
+		ContainerName:  containerName,
+		PacketsRelayed: packetsRelayed,
+		FeesSpent:      feesSpent,
+	}
+}
+
 // TestifyT returns a TestifyReporter which will track logged errors in test.
 // Typically you will use this with the New method on the require or assert package:
 //     req := require.New(reporter.TestifyT(t))
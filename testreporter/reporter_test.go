@@ -261,6 +261,101 @@ func TestReporter_RelayerExec(t *testing.T) {
 	require.Empty(t, diff)
 }
 
+func TestReporter_RelayerLog(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+
+	r.TrackTest(mt)
+
+	loggedAt := time.Now()
+	r.RelayerExecReporter(mt).TrackRelayerLog(
+		"my_container",
+		loggedAt,
+		"info", "Successful transaction",
+		map[string]string{"chain_id": "gaia-1"},
+	)
+
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 5)
+
+	diff := cmp.Diff(testreporter.RelayerLogMessage{
+		Name:          "my_test",
+		When:          loggedAt,
+		ContainerName: "my_container",
+		Level:         "info",
+		Message:       "Successful transaction",
+		Fields:        map[string]string{"chain_id": "gaia-1"},
+	}, msgs[2].(testreporter.RelayerLogMessage))
+	require.Empty(t, diff)
+}
+
+func TestReporter_RelayerLogAfterClose(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+
+	r.TrackTest(mt)
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	// A relayer's logs may still be streamed after the tests finish.
+	require.NotPanics(t, func() {
+		r.RelayerExecReporter(mt).TrackRelayerLog("my_container", time.Now(), "info", "Late line", nil)
+	})
+
+	// Only the suite and test messages were written.
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 4)
+	require.IsType(t, testreporter.FinishSuiteMessage{}, msgs[3])
+}
+
+func TestReporter_RelayerEfficiency(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+
+	r.TrackTest(mt)
+
+	scrapedAt := time.Now()
+	r.RelayerExecReporter(mt).TrackRelayerEfficiency(
+		"my_container",
+		scrapedAt,
+		4,
+		map[string]float64{"uatom": 1500},
+	)
+
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 5)
+
+	diff := cmp.Diff(testreporter.RelayerEfficiencyMessage{
+		Name:           "my_test",
+		When:           scrapedAt,
+		ContainerName:  "my_container",
+		PacketsRelayed: 4,
+		FeesSpent:      map[string]float64{"uatom": 1500},
+	}, msgs[2].(testreporter.RelayerEfficiencyMessage))
+	require.Empty(t, diff)
+}
+
 // requireTimeInRange is a helper to assert that a time occurs between a given start and end.
 func requireTimeInRange(t *testing.T, actual, notBefore, notAfter time.Time) {
 	t.Helper()
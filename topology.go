@@ -0,0 +1,100 @@
+package ibctest
+
+import "fmt"
+
+// Topology is the shape of the links between the chains of a set of more than two chains.
+type Topology string
+
+const (
+	// TopologyLine links each chain to the next one in the chain set.
+	TopologyLine Topology = "line"
+
+	// TopologyHubAndSpoke links the first chain in the chain set, the hub, to every other chain.
+	TopologyHubAndSpoke Topology = "hub-and-spoke"
+
+	// TopologyFullMesh links every pair of chains.
+	TopologyFullMesh Topology = "full-mesh"
+)
+
+// Validate returns an error if t is not a known topology.
+func (t Topology) Validate() error {
+	switch t {
+	case TopologyLine, TopologyHubAndSpoke, TopologyFullMesh:
+		return nil
+	default:
+		return fmt.Errorf("unknown topology %q (valid topologies: %s, %s, %s)", t, TopologyLine, TopologyHubAndSpoke, TopologyFullMesh)
+	}
+}
+
+// Edges returns the pairs of indices of the linked chains in a set of n chains,
+// with the lower index first.
+func (t Topology) Edges(n int) ([][2]int, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	if n < 2 {
+		return nil, fmt.Errorf("topology %s needs at least 2 chains (found %d)", t, n)
+	}
+
+	var edges [][2]int
+	switch t {
+	case TopologyLine:
+		for i := 0; i+1 < n; i++ {
+			edges = append(edges, [2]int{i, i + 1})
+		}
+	case TopologyHubAndSpoke:
+		for i := 1; i < n; i++ {
+			edges = append(edges, [2]int{0, i})
+		}
+	case TopologyFullMesh:
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				edges = append(edges, [2]int{i, j})
+			}
+		}
+	}
+	return edges, nil
+}
+
+// Route returns the indices of the chains along the longest route
+// that a greedy walk over the edges of t finds in a set of n chains,
+// starting from a chain with a single link if there is one.
+// Each consecutive pair of chains in the route is linked.
+func (t Topology) Route(n int) ([]int, error) {
+	edges, err := t.Edges(n)
+	if err != nil {
+		return nil, err
+	}
+
+	neighbors := make([][]int, n)
+	for _, e := range edges {
+		neighbors[e[0]] = append(neighbors[e[0]], e[1])
+		neighbors[e[1]] = append(neighbors[e[1]], e[0])
+	}
+
+	start := 0
+	for i, ns := range neighbors {
+		if len(ns) == 1 {
+			start = i
+			break
+		}
+	}
+
+	route := []int{start}
+	visited := map[int]bool{start: true}
+	for cur := start; ; {
+		next := -1
+		for _, nb := range neighbors[cur] {
+			if !visited[nb] {
+				next = nb
+				break
+			}
+		}
+		if next < 0 {
+			return route, nil
+		}
+		route = append(route, next)
+		visited[next] = true
+		cur = next
+	}
+}
@@ -0,0 +1,78 @@
+package ibctest_test
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTopology_Edges(t *testing.T) {
+	for _, tc := range []struct {
+		topology ibctest.Topology
+		edges    [][2]int
+		route    []int
+	}{
+		{
+			topology: ibctest.TopologyLine,
+			edges:    [][2]int{{0, 1}, {1, 2}, {2, 3}},
+			route:    []int{0, 1, 2, 3},
+		},
+		{
+			topology: ibctest.TopologyHubAndSpoke,
+			edges:    [][2]int{{0, 1}, {0, 2}, {0, 3}},
+			route:    []int{1, 0, 2},
+		},
+		{
+			topology: ibctest.TopologyFullMesh,
+			edges:    [][2]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}},
+			route:    []int{0, 1, 2, 3},
+		},
+	} {
+		tc := tc
+		t.Run(string(tc.topology), func(t *testing.T) {
+			edges, err := tc.topology.Edges(4)
+			require.NoError(t, err)
+			require.Equal(t, tc.edges, edges)
+
+			route, err := tc.topology.Route(4)
+			require.NoError(t, err)
+			require.Equal(t, tc.route, route)
+		})
+	}
+
+	t.Run("unknown topology", func(t *testing.T) {
+		_, err := ibctest.Topology("ring").Edges(4)
+		require.Error(t, err)
+	})
+
+	t.Run("too few chains", func(t *testing.T) {
+		_, err := ibctest.TopologyLine.Edges(1)
+		require.Error(t, err)
+	})
+}
+
+func TestBuiltinChainFactory_WithTopology(t *testing.T) {
+	cf := ibctest.NewBuiltinChainFactory(zap.NewNop(), []*ibctest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.1"},
+		{Name: "osmosis", Version: "v11.0.0"},
+		{Name: "juno", Version: "v9.0.0"},
+	})
+
+	// Without a topology, callers cannot mistake the factory for one of linked chains.
+	var plain ibctest.ChainFactory = cf
+	_, ok := plain.(ibctest.TopologyChainFactory)
+	require.False(t, ok)
+
+	tcf := cf.WithTopology(ibctest.TopologyLine)
+	require.Equal(t, ibctest.TopologyLine, tcf.Topology())
+	require.Equal(t, 3, tcf.Count())
+	require.Equal(t, "line:"+cf.Name(), tcf.Name())
+
+	pair := tcf.Pair(1, 2)
+	require.Equal(t, 2, pair.Count())
+	require.NotContains(t, pair.Name(), "gaia")
+	_, ok = pair.(ibctest.TopologyChainFactory)
+	require.False(t, ok)
+}